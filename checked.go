@@ -0,0 +1,798 @@
+package randshiro
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Matches every *ArgumentError when used with errors.Is()
+var ErrInvalidArgument = errors.New("randshiro: invalid argument")
+
+// Returned by the methods of Checked when an argument is out of range
+type ArgumentError struct {
+	// Name of the method that was called, e.g. "Uint64n"
+	Method string
+	// Name of the offending parameter, e.g. "bound"
+	Argument string
+	// Value that was passed for the offending parameter
+	Value any
+	// Why the value was rejected, e.g. "must be greater than 0"
+	Reason string
+}
+
+// Returns a message naming the method, the argument and its value, and why it was rejected
+func (err *ArgumentError) Error() string {
+	return fmt.Sprintf("randshiro: %s called with %s = %v: %s", err.Method, err.Argument, err.Value, err.Reason)
+}
+
+// Reports whether target is ErrInvalidArgument, so that errors.Is()
+// matches every *ArgumentError against it regardless of its fields
+func (err *ArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+func argumentError(method, argument string, value any, reason string) error {
+	return &ArgumentError{Method: method, Argument: argument, Value: value, Reason: reason}
+}
+
+// Wraps a *Gen so that methods which would otherwise panic or silently return
+// nonsense on bad input instead validate every argument and return an *ArgumentError
+//
+// Methods that can't misbehave are promoted from the embedded *Gen unchanged.
+// Intended for code that passes user-provided bounds straight through;
+// prefer the unchecked methods of *Gen everywhere else
+type Checked struct{ *Gen }
+
+// Returns a Checked wrapping rng
+func NewChecked(rng *Gen) Checked {
+	return Checked{Gen: rng}
+}
+
+// Returns a uint64 in the interval [0, 2^bitcount)
+//
+// Returns an error unless bitcount is in the interval [1, 64]
+func (rng Checked) Uint64bits(bitcount uint) (uint64, error) {
+	if bitcount == 0 || bitcount > 64 {
+		return 0, argumentError("Uint64bits", "bitcount", bitcount, "must be in the interval [1, 64]")
+	}
+	return rng.Gen.Uint64bits(bitcount), nil
+}
+
+// Returns a uint64 in the interval [0, bound)
+//
+// Returns an error if bound == 0
+func (rng Checked) Uint64n(bound uint64) (uint64, error) {
+	if bound == 0 {
+		return 0, argumentError("Uint64n", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Uint64n(bound), nil
+}
+
+// Returns a uint32 in the interval [0, bound)
+//
+// Returns an error if bound == 0
+func (rng Checked) Uint32n(bound uint32) (uint32, error) {
+	if bound == 0 {
+		return 0, argumentError("Uint32n", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Uint32n(bound), nil
+}
+
+// Returns two independent uint32s in the interval [0, bound)
+//
+// Returns an error if bound == 0
+func (rng Checked) FastUint32n(bound uint32) (uint32, uint32, error) {
+	if bound == 0 {
+		return 0, 0, argumentError("FastUint32n", "bound", bound, "must be greater than 0")
+	}
+	var x, y = rng.Gen.FastUint32n(bound)
+	return x, y, nil
+}
+
+// Returns an int in the interval [0, bound)
+//
+// Returns an error if bound <= 0
+func (rng Checked) Intn(bound int) (int, error) {
+	if bound <= 0 {
+		return 0, argumentError("Intn", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Intn(bound), nil
+}
+
+// Returns an int64 in the interval [0, bound)
+//
+// Returns an error if bound <= 0
+func (rng Checked) Int64n(bound int64) (int64, error) {
+	if bound <= 0 {
+		return 0, argumentError("Int64n", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Int64n(bound), nil
+}
+
+// Returns an int32 in the interval [0, bound)
+//
+// Returns an error if bound <= 0
+func (rng Checked) Int32n(bound int32) (int32, error) {
+	if bound <= 0 {
+		return 0, argumentError("Int32n", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Int32n(bound), nil
+}
+
+// Returns an int64 in the interval [0, bound)
+//
+// Returns an error if bound <= 0
+func (rng Checked) Int63n(bound int64) (int64, error) {
+	if bound <= 0 {
+		return 0, argumentError("Int63n", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Int63n(bound), nil
+}
+
+// Returns an int32 in the interval [0, bound)
+//
+// Returns an error if bound <= 0
+func (rng Checked) Int31n(bound int32) (int32, error) {
+	if bound <= 0 {
+		return 0, argumentError("Int31n", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.Int31n(bound), nil
+}
+
+// Returns an int in the interval [lowerBound, upperBound)
+//
+// Returns an error if lowerBound >= upperBound
+func (rng Checked) IntRange(lowerBound, upperBound int) (int, error) {
+	if lowerBound >= upperBound {
+		return 0, argumentError("IntRange", "upperBound", upperBound, fmt.Sprintf("must be greater than lowerBound (%d)", lowerBound))
+	}
+	return rng.Gen.IntRange(lowerBound, upperBound), nil
+}
+
+// Returns an int64 in the interval [lowerBound, upperBound)
+//
+// Returns an error if lowerBound >= upperBound
+func (rng Checked) Int64Range(lowerBound, upperBound int64) (int64, error) {
+	if lowerBound >= upperBound {
+		return 0, argumentError("Int64Range", "upperBound", upperBound, fmt.Sprintf("must be greater than lowerBound (%d)", lowerBound))
+	}
+	return rng.Gen.Int64Range(lowerBound, upperBound), nil
+}
+
+// Returns a uint64 in the interval [lowerBound, upperBound)
+//
+// Returns an error if lowerBound >= upperBound
+func (rng Checked) Uint64Range(lowerBound, upperBound uint64) (uint64, error) {
+	if lowerBound >= upperBound {
+		return 0, argumentError("Uint64Range", "upperBound", upperBound, fmt.Sprintf("must be greater than lowerBound (%d)", lowerBound))
+	}
+	return rng.Gen.Uint64Range(lowerBound, upperBound), nil
+}
+
+// Returns an int in the interval [lowerBound, upperBound]
+//
+// Returns an error if lowerBound > upperBound
+func (rng Checked) IntBetween(lowerBound, upperBound int) (int, error) {
+	if lowerBound > upperBound {
+		return 0, argumentError("IntBetween", "upperBound", upperBound, fmt.Sprintf("must not be less than lowerBound (%d)", lowerBound))
+	}
+	return rng.Gen.IntBetween(lowerBound, upperBound), nil
+}
+
+// Returns an int64 in the interval [lowerBound, upperBound]
+//
+// Returns an error if lowerBound > upperBound
+func (rng Checked) Int64Between(lowerBound, upperBound int64) (int64, error) {
+	if lowerBound > upperBound {
+		return 0, argumentError("Int64Between", "upperBound", upperBound, fmt.Sprintf("must not be less than lowerBound (%d)", lowerBound))
+	}
+	return rng.Gen.Int64Between(lowerBound, upperBound), nil
+}
+
+// Returns a uint64 in the interval [lowerBound, upperBound]
+//
+// Returns an error if lowerBound > upperBound
+func (rng Checked) Uint64Between(lowerBound, upperBound uint64) (uint64, error) {
+	if lowerBound > upperBound {
+		return 0, argumentError("Uint64Between", "upperBound", upperBound, fmt.Sprintf("must not be less than lowerBound (%d)", lowerBound))
+	}
+	return rng.Gen.Uint64Between(lowerBound, upperBound), nil
+}
+
+// Returns a bool with n in m odds of being true
+//
+// Returns an error if m == 0 or n > m
+func (rng Checked) Odds(n, m uint64) (bool, error) {
+	if m == 0 {
+		return false, argumentError("Odds", "m", m, "must be greater than 0")
+	}
+	if n > m {
+		return false, argumentError("Odds", "n", n, fmt.Sprintf("must not be greater than m (%d)", m))
+	}
+	return rng.Gen.Odds(n, m), nil
+}
+
+// Returns a uniformly distributed *big.Int in the interval [0, bound)
+//
+// Returns an error if bound is nil or bound <= 0
+func (rng Checked) BigIntn(bound *big.Int) (*big.Int, error) {
+	if bound == nil || bound.Sign() <= 0 {
+		return nil, argumentError("BigIntn", "bound", bound, "must be greater than 0")
+	}
+	return rng.Gen.BigIntn(bound), nil
+}
+
+// Returns the high and low halves of a uint128 in the interval [0, bound)
+//
+// Returns an error if bound == 0
+func (rng Checked) Uint128n(boundHi, boundLo uint64) (uint64, uint64, error) {
+	if boundHi == 0 && boundLo == 0 {
+		return 0, 0, argumentError("Uint128n", "bound", 0, "must be greater than 0")
+	}
+	var hi, lo = rng.Gen.Uint128n(boundHi, boundLo)
+	return hi, lo, nil
+}
+
+// Returns a uniformly distributed float64 in the interval [lowerBound, upperBound)
+//
+// Returns an error unless both bounds are finite and lowerBound < upperBound
+func (rng Checked) Float64Range(lowerBound, upperBound float64) (float64, error) {
+	if err := checkFinite("Float64Range", "lowerBound", lowerBound); err != nil {
+		return 0, err
+	}
+	if err := checkFinite("Float64Range", "upperBound", upperBound); err != nil {
+		return 0, err
+	}
+	if lowerBound >= upperBound {
+		return 0, argumentError("Float64Range", "upperBound", upperBound, fmt.Sprintf("must be greater than lowerBound (%v)", lowerBound))
+	}
+	return rng.Gen.Float64Range(lowerBound, upperBound), nil
+}
+
+// Returns a uniformly distributed float32 in the interval [lowerBound, upperBound)
+//
+// Returns an error unless both bounds are finite and lowerBound < upperBound
+func (rng Checked) Float32Range(lowerBound, upperBound float32) (float32, error) {
+	if err := checkFinite("Float32Range", "lowerBound", float64(lowerBound)); err != nil {
+		return 0, err
+	}
+	if err := checkFinite("Float32Range", "upperBound", float64(upperBound)); err != nil {
+		return 0, err
+	}
+	if lowerBound >= upperBound {
+		return 0, argumentError("Float32Range", "upperBound", upperBound, fmt.Sprintf("must be greater than lowerBound (%v)", lowerBound))
+	}
+	return rng.Gen.Float32Range(lowerBound, upperBound), nil
+}
+
+// Returns two independent and normally distributed float64s
+// with user-defined mean and stddev
+//
+// Returns an error if mean is not finite or stddev is not finite and non-negative
+func (rng Checked) NormalDist(mean, stddev float64) (float64, float64, error) {
+	if err := checkFinite("NormalDist", "mean", mean); err != nil {
+		return 0, 0, err
+	}
+	if err := checkNonNegative("NormalDist", "stddev", stddev); err != nil {
+		return 0, 0, err
+	}
+	var x, y = rng.Gen.NormalDist(mean, stddev)
+	return x, y, nil
+}
+
+// Returns two independent and normally distributed float32s
+// with user-defined mean and stddev
+//
+// Returns an error if mean is not finite or stddev is not finite and non-negative
+func (rng Checked) NormalDistFloat32(mean, stddev float32) (float32, float32, error) {
+	if err := checkFinite("NormalDistFloat32", "mean", float64(mean)); err != nil {
+		return 0, 0, err
+	}
+	if err := checkNonNegative("NormalDistFloat32", "stddev", float64(stddev)); err != nil {
+		return 0, 0, err
+	}
+	var x, y = rng.Gen.NormalDistFloat32(mean, stddev)
+	return x, y, nil
+}
+
+// Returns a log-normally distributed float64: the exponential of a normally
+// distributed float64 with mean mu and stddev sigma
+//
+// Returns an error unless mu is finite and sigma is finite and greater than 0
+func (rng Checked) Lognormal(mu, sigma float64) (float64, error) {
+	if err := checkFinite("Lognormal", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Lognormal", "sigma", sigma); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Lognormal(mu, sigma), nil
+}
+
+// Returns a Weibull distributed float64 with shape k and scale lambda
+//
+// Returns an error unless k and lambda are finite and greater than 0
+func (rng Checked) Weibull(k, lambda float64) (float64, error) {
+	if err := checkPositive("Weibull", "k", k); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Weibull", "lambda", lambda); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Weibull(k, lambda), nil
+}
+
+// Returns a Pareto distributed float64 with scale (minimum value) xm
+// and shape (tail index) alpha
+//
+// Returns an error unless xm and alpha are finite and greater than 0
+func (rng Checked) Pareto(xm, alpha float64) (float64, error) {
+	if err := checkPositive("Pareto", "xm", xm); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Pareto", "alpha", alpha); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Pareto(xm, alpha), nil
+}
+
+// Returns a Cauchy distributed float64 with location x0 and scale gamma
+//
+// Returns an error unless x0 is finite and gamma is finite and greater than 0
+func (rng Checked) Cauchy(x0, gamma float64) (float64, error) {
+	if err := checkFinite("Cauchy", "x0", x0); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Cauchy", "gamma", gamma); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Cauchy(x0, gamma), nil
+}
+
+// Returns a Laplace (double exponential) distributed float64
+// with location mu and scale b
+//
+// Returns an error unless mu is finite and b is finite and greater than 0
+func (rng Checked) Laplace(mu, b float64) (float64, error) {
+	if err := checkFinite("Laplace", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Laplace", "b", b); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Laplace(mu, b), nil
+}
+
+// Returns a logistically distributed float64 with location mu and scale s
+//
+// Returns an error unless mu is finite and s is finite and greater than 0
+func (rng Checked) Logistic(mu, s float64) (float64, error) {
+	if err := checkFinite("Logistic", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Logistic", "s", s); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Logistic(mu, s), nil
+}
+
+// Returns a Gumbel (type I extreme value) distributed float64
+// with location mu and scale beta
+//
+// Returns an error unless mu is finite and beta is finite and greater than 0
+func (rng Checked) Gumbel(mu, beta float64) (float64, error) {
+	if err := checkFinite("Gumbel", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Gumbel", "beta", beta); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Gumbel(mu, beta), nil
+}
+
+// Returns a gamma distributed float64 with shape k and scale theta
+//
+// Returns an error unless k and theta are finite and greater than 0
+func (rng Checked) Gamma(k, theta float64) (float64, error) {
+	if err := checkPositive("Gamma", "k", k); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Gamma", "theta", theta); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Gamma(k, theta), nil
+}
+
+// Returns a chi-squared distributed float64 with k degrees of freedom
+//
+// Returns an error unless k is finite and greater than 0
+func (rng Checked) ChiSquared(k float64) (float64, error) {
+	if err := checkPositive("ChiSquared", "k", k); err != nil {
+		return 0, err
+	}
+	return rng.Gen.ChiSquared(k), nil
+}
+
+// Returns a Student's t distributed float64 with nu degrees of freedom
+//
+// Returns an error unless nu is finite and greater than 0
+func (rng Checked) StudentT(nu float64) (float64, error) {
+	if err := checkPositive("StudentT", "nu", nu); err != nil {
+		return 0, err
+	}
+	return rng.Gen.StudentT(nu), nil
+}
+
+// Returns an F distributed float64 with d1 and d2 degrees of freedom
+//
+// Returns an error unless d1 and d2 are finite and greater than 0
+func (rng Checked) FDist(d1, d2 float64) (float64, error) {
+	if err := checkPositive("FDist", "d1", d1); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("FDist", "d2", d2); err != nil {
+		return 0, err
+	}
+	return rng.Gen.FDist(d1, d2), nil
+}
+
+// Returns a noncentral chi-squared distributed float64 with k degrees
+// of freedom and noncentrality parameter lambda
+//
+// Returns an error unless k is finite and greater than 0 and lambda is finite and non-negative
+func (rng Checked) NoncentralChiSquared(k, lambda float64) (float64, error) {
+	if err := checkPositive("NoncentralChiSquared", "k", k); err != nil {
+		return 0, err
+	}
+	if err := checkNonNegative("NoncentralChiSquared", "lambda", lambda); err != nil {
+		return 0, err
+	}
+	return rng.Gen.NoncentralChiSquared(k, lambda), nil
+}
+
+// Returns a Poisson distributed int with the given mean
+//
+// Returns an error unless mean is non-negative and small enough
+// that the result fits in an int
+func (rng Checked) Poisson(mean float64) (int, error) {
+	// Leaves plenty of room for the tail above the mean
+	const maxMean = math.MaxInt32
+	if err := checkNonNegative("Poisson", "mean", mean); err != nil {
+		return 0, err
+	}
+	if mean > maxMean {
+		return 0, argumentError("Poisson", "mean", mean, fmt.Sprintf("must not be greater than %d", maxMean))
+	}
+	return rng.Gen.Poisson(mean), nil
+}
+
+// Returns a normally distributed float64 with user-defined mean and stddev,
+// truncated to the interval [lowerBound, upperBound]
+//
+// Returns an error unless mean is finite, stddev is finite and greater than 0,
+// and lowerBound <= upperBound (lowerBound may be -Inf and upperBound may be +Inf)
+func (rng Checked) TruncatedNormal(mean, stddev, lowerBound, upperBound float64) (float64, error) {
+	if err := checkFinite("TruncatedNormal", "mean", mean); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("TruncatedNormal", "stddev", stddev); err != nil {
+		return 0, err
+	}
+	if math.IsNaN(lowerBound) || math.IsInf(lowerBound, 1) {
+		return 0, argumentError("TruncatedNormal", "lowerBound", lowerBound, "must be a number less than +Inf")
+	}
+	if math.IsNaN(upperBound) || math.IsInf(upperBound, -1) {
+		return 0, argumentError("TruncatedNormal", "upperBound", upperBound, "must be a number greater than -Inf")
+	}
+	if !(lowerBound <= upperBound) {
+		return 0, argumentError("TruncatedNormal", "upperBound", upperBound, fmt.Sprintf("must not be less than lowerBound (%v)", lowerBound))
+	}
+	return rng.Gen.TruncatedNormal(mean, stddev, lowerBound, upperBound), nil
+}
+
+// Returns an exponentially distributed float64 with rate constant lambda,
+// truncated to the interval [0.0, upperBound)
+//
+// Returns an error unless lambda is finite and greater than 0 and upperBound is greater than 0
+func (rng Checked) TruncatedExponential(lambda, upperBound float64) (float64, error) {
+	if err := checkPositive("TruncatedExponential", "lambda", lambda); err != nil {
+		return 0, err
+	}
+	if !(upperBound > 0) {
+		return 0, argumentError("TruncatedExponential", "upperBound", upperBound, "must be greater than 0")
+	}
+	return rng.Gen.TruncatedExponential(lambda, upperBound), nil
+}
+
+// Returns a beta distributed float64 in the interval [0.0, 1.0]
+// with shape parameters a and b
+//
+// Returns an error unless a and b are finite and greater than 0
+func (rng Checked) Beta(a, b float64) (float64, error) {
+	if err := checkPositive("Beta", "a", a); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("Beta", "b", b); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Beta(a, b), nil
+}
+
+// Returns a binomially distributed int: the number of successes
+// in n independent trials that each succeed with probability p
+//
+// Returns an error if n < 0 or p is not in the interval [0.0, 1.0]
+func (rng Checked) Binomial(n int, p float64) (int, error) {
+	if n < 0 {
+		return 0, argumentError("Binomial", "n", n, "must not be negative")
+	}
+	if err := checkProbability("Binomial", "p", p); err != nil {
+		return 0, err
+	}
+	return rng.Gen.Binomial(n, p), nil
+}
+
+// Returns a Dirichlet distributed vector with concentration parameters alpha
+//
+// Returns an error if alpha is empty or any of its elements are not finite and greater than 0
+func (rng Checked) Dirichlet(alpha []float64) ([]float64, error) {
+	if len(alpha) == 0 {
+		return nil, argumentError("Dirichlet", "alpha", alpha, "must not be empty")
+	}
+	for i, a := range alpha {
+		if err := checkPositive("Dirichlet", fmt.Sprintf("alpha[%d]", i), a); err != nil {
+			return nil, err
+		}
+	}
+	return rng.Gen.Dirichlet(alpha), nil
+}
+
+// Returns multinomially distributed counts: how many of n independent trials
+// landed in each category, where category i is chosen with probability p[i]
+//
+// Returns an error if n < 0, p is empty, any element of p is not finite
+// and non-negative, or every element of p is zero
+func (rng Checked) Multinomial(n int, p []float64) ([]int, error) {
+	if n < 0 {
+		return nil, argumentError("Multinomial", "n", n, "must not be negative")
+	}
+	if err := checkWeights("Multinomial", "p", p); err != nil {
+		return nil, err
+	}
+	return rng.Gen.Multinomial(n, p), nil
+}
+
+// Fills dst with a uniformly distributed point on the surface of the
+// unit sphere in len(dst) dimensions and returns it
+//
+// Returns an error if dst is empty
+func (rng Checked) UnitSphere(dst []float64) ([]float64, error) {
+	if len(dst) == 0 {
+		return nil, argumentError("UnitSphere", "len(dst)", 0, "must be at least 1")
+	}
+	return rng.Gen.UnitSphere(dst), nil
+}
+
+// Fills dst with a uniformly distributed point inside the
+// unit ball in len(dst) dimensions and returns it
+//
+// Returns an error if dst is empty
+func (rng Checked) UnitBall(dst []float64) ([]float64, error) {
+	if len(dst) == 0 {
+		return nil, argumentError("UnitBall", "len(dst)", 0, "must be at least 1")
+	}
+	return rng.Gen.UnitBall(dst), nil
+}
+
+// Fills dst with a uniformly distributed point on the probability simplex
+// in len(dst) dimensions and returns it
+//
+// Returns an error if dst is empty
+func (rng Checked) Simplex(dst []float64) ([]float64, error) {
+	if len(dst) == 0 {
+		return nil, argumentError("Simplex", "len(dst)", 0, "must be at least 1")
+	}
+	return rng.Gen.Simplex(dst), nil
+}
+
+// Returns a uniformly distributed point inside the annulus (ring) centered on the
+// origin between the circles of radius innerRadius and outerRadius
+//
+// Returns an error unless 0 <= innerRadius <= outerRadius and both are finite
+func (rng Checked) Annulus(innerRadius, outerRadius float64) (float64, float64, error) {
+	if err := checkNonNegative("Annulus", "innerRadius", innerRadius); err != nil {
+		return 0, 0, err
+	}
+	if err := checkNonNegative("Annulus", "outerRadius", outerRadius); err != nil {
+		return 0, 0, err
+	}
+	if outerRadius < innerRadius {
+		return 0, 0, argumentError("Annulus", "outerRadius", outerRadius, fmt.Sprintf("must not be less than innerRadius (%v)", innerRadius))
+	}
+	var x, y = rng.Gen.Annulus(innerRadius, outerRadius)
+	return x, y, nil
+}
+
+// Returns a uniformly distributed point inside the triangle with vertices a, b, and c
+//
+// Returns an error unless every coordinate is finite
+func (rng Checked) Triangle(a, b, c [2]float64) (float64, float64, error) {
+	for i, vertex := range [3][2]float64{a, b, c} {
+		for j, x := range vertex {
+			if err := checkFinite("Triangle", fmt.Sprintf("%c[%d]", 'a'+i, j), x); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	var x, y = rng.Gen.Triangle(a, b, c)
+	return x, y, nil
+}
+
+// Returns a Yule–Simon distributed uint64 in the interval [1, 2^64) with shape rho
+//
+// Returns an error unless rho is finite and greater than 0
+func (rng Checked) YuleSimon(rho float64) (uint64, error) {
+	if err := checkPositive("YuleSimon", "rho", rho); err != nil {
+		return 0, err
+	}
+	return rng.Gen.YuleSimon(rho), nil
+}
+
+// Returns a von Mises distributed angle in the interval [-pi, pi)
+// with mean direction mu and concentration kappa
+//
+// Returns an error unless mu is finite and kappa is finite and non-negative
+func (rng Checked) VonMises(mu, kappa float64) (float64, error) {
+	if err := checkFinite("VonMises", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkNonNegative("VonMises", "kappa", kappa); err != nil {
+		return 0, err
+	}
+	return rng.Gen.VonMises(mu, kappa), nil
+}
+
+// Returns a wrapped Cauchy distributed angle in the interval [-pi, pi)
+// with mean direction mu and scale gamma
+//
+// Returns an error unless mu is finite and gamma is finite and greater than 0
+func (rng Checked) WrappedCauchy(mu, gamma float64) (float64, error) {
+	if err := checkFinite("WrappedCauchy", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkPositive("WrappedCauchy", "gamma", gamma); err != nil {
+		return 0, err
+	}
+	return rng.Gen.WrappedCauchy(mu, gamma), nil
+}
+
+// Returns a wrapped normal distributed angle in the interval [-pi, pi)
+// with mean direction mu and stddev sigma (before wrapping)
+//
+// Returns an error unless mu is finite and sigma is finite and non-negative
+func (rng Checked) WrappedNormal(mu, sigma float64) (float64, error) {
+	if err := checkFinite("WrappedNormal", "mu", mu); err != nil {
+		return 0, err
+	}
+	if err := checkNonNegative("WrappedNormal", "sigma", sigma); err != nil {
+		return 0, err
+	}
+	return rng.Gen.WrappedNormal(mu, sigma), nil
+}
+
+// Returns a permutation of ints in the interval [0, n)
+//
+// Returns an error if n < 0
+func (rng Checked) Perm(n int) ([]int, error) {
+	if n < 0 {
+		return nil, argumentError("Perm", "n", n, "must not be negative")
+	}
+	return rng.Gen.Perm(n), nil
+}
+
+// Returns a uniformly random k-combination of ints in the interval [0, n),
+// sorted in increasing order
+//
+// Returns an error unless 0 <= k <= n
+func (rng Checked) Combination(n, k int) ([]int, error) {
+	if n < 0 {
+		return nil, argumentError("Combination", "n", n, "must not be negative")
+	}
+	if k < 0 || k > n {
+		return nil, argumentError("Combination", "k", k, fmt.Sprintf("must be in the interval [0, n] (n = %d)", n))
+	}
+	return rng.Gen.Combination(n, k), nil
+}
+
+// Returns the ints in the interval [0, n) that were each independently
+// chosen with probability p, sorted in increasing order
+//
+// Returns an error if n < 0 or p is not in the interval [0.0, 1.0]
+func (rng Checked) Subset(n int, p float64) ([]int, error) {
+	if n < 0 {
+		return nil, argumentError("Subset", "n", n, "must not be negative")
+	}
+	if err := checkProbability("Subset", "p", p); err != nil {
+		return nil, err
+	}
+	return rng.Gen.Subset(n, p), nil
+}
+
+// Returns a uniformly random partition of n: a slice of positive ints
+// in non-increasing order that sum to n
+//
+// Returns an error if n < 0
+func (rng Checked) Partition(n int) ([]int, error) {
+	if n < 0 {
+		return nil, argumentError("Partition", "n", n, "must not be negative")
+	}
+	return rng.Gen.Partition(n), nil
+}
+
+// Returns a uniformly random composition of n: a slice of positive ints
+// that sum to n where, unlike Partition(), the order of the parts matters
+//
+// Returns an error if n < 0
+func (rng Checked) Composition(n int) ([]int, error) {
+	if n < 0 {
+		return nil, argumentError("Composition", "n", n, "must not be negative")
+	}
+	return rng.Gen.Composition(n), nil
+}
+
+// Returns a Haar distributed (uniformly random) n by n orthogonal matrix, as rows
+//
+// Returns an error if n < 0
+func (rng Checked) RandomOrthogonal(n int) ([][]float64, error) {
+	if n < 0 {
+		return nil, argumentError("RandomOrthogonal", "n", n, "must not be negative")
+	}
+	return rng.Gen.RandomOrthogonal(n), nil
+}
+
+func checkFinite(method, argument string, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return argumentError(method, argument, value, "must be finite")
+	}
+	return nil
+}
+
+func checkPositive(method, argument string, value float64) error {
+	if !(value > 0) || math.IsInf(value, 1) {
+		return argumentError(method, argument, value, "must be finite and greater than 0")
+	}
+	return nil
+}
+
+func checkNonNegative(method, argument string, value float64) error {
+	if !(value >= 0) || math.IsInf(value, 1) {
+		return argumentError(method, argument, value, "must be finite and not negative")
+	}
+	return nil
+}
+
+func checkProbability(method, argument string, value float64) error {
+	if !(value >= 0 && value <= 1) {
+		return argumentError(method, argument, value, "must be in the interval [0.0, 1.0]")
+	}
+	return nil
+}
+
+func checkWeights(method, argument string, weights []float64) error {
+	if len(weights) == 0 {
+		return argumentError(method, argument, weights, "must not be empty")
+	}
+	var sum float64
+	for i, weight := range weights {
+		if err := checkNonNegative(method, fmt.Sprintf("%s[%d]", argument, i), weight); err != nil {
+			return err
+		}
+		sum += weight
+	}
+	if !(sum > 0) || math.IsInf(sum, 1) {
+		return argumentError(method, argument, weights, "must have a finite sum greater than 0")
+	}
+	return nil
+}
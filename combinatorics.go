@@ -0,0 +1,139 @@
+package randshiro
+
+import (
+	"math"
+	"sort"
+)
+
+// Returns a uniformly random k-combination of ints in the interval [0, n),
+// sorted in increasing order
+//
+// Makes no range checks on n/k
+func (rng *Gen) Combination(n, k int) []int {
+	// Robert Floyd's algorithm: each iteration either picks a fresh value
+	// in [0, j] or, if that value was already picked, picks j itself
+	var picked = make(map[int]struct{}, k)
+	var combination = make([]int, 0, k)
+	for j := n - k; j < n; j++ {
+		var value = rng.Intn(j + 1)
+		if _, ok := picked[value]; ok {
+			value = j
+		}
+		picked[value] = struct{}{}
+		combination = append(combination, value)
+	}
+	sort.Ints(combination)
+	return combination
+}
+
+// Returns the ints in the interval [0, n) that were each independently
+// chosen with probability p, sorted in increasing order
+//
+// Runs in time proportional to the size of the returned subset
+// instead of n by skipping geometrically distributed gaps
+func (rng *Gen) Subset(n int, p float64) []int {
+	if p <= 0 || n <= 0 {
+		return []int{}
+	}
+	if p >= 1 {
+		var subset = make([]int, n)
+		for i := range subset {
+			subset[i] = i
+		}
+		return subset
+	}
+	var subset = make([]int, 0, int(float64(n)*p)+1)
+	var last = uint64(n - 1)
+	for i := rng.geometric(p); i <= last; {
+		subset = append(subset, int(i))
+		var gap = rng.geometric(p)
+		if gap >= last-i {
+			break
+		}
+		i += gap + 1
+	}
+	return subset
+}
+
+// Returns a uniformly random partition of n: a slice of positive ints
+// in non-increasing order that sum to n
+//
+// Returns an empty slice if n <= 0
+func (rng *Gen) Partition(n int) []int {
+	if n <= 0 {
+		return []int{}
+	}
+	// Fristedt's method: with x = exp(-c), drawing the multiplicity of each
+	// part size k from an independent geometric distribution with ratio x^k
+	// makes every partition of a given size equally likely.
+	// c is chosen so that the expected sum is (approximately) n.
+	// Instead of rejecting until the parts happen to sum to n, the
+	// multiplicity of 1 is forced to make up the remainder r and kept with
+	// probability x^r (Arratia and DeSalvo's probabilistic divide-and-conquer)
+	var c = math.Pi / math.Sqrt(6*float64(n))
+	var counts = make([]int, n+1)
+retry:
+	var sum = 0
+	for k := 2; k <= n; k++ {
+		// Geometric with ratio exp(-c*k), via inversion
+		var count = math.Floor(rng.Exponential() / (c * float64(k)))
+		if count*float64(k) > float64(n-sum) {
+			goto retry
+		}
+		counts[k] = int(count)
+		sum += counts[k] * k
+	}
+	counts[1] = n - sum
+	// Exponential() >= c*r happens with probability exp(-c*r)
+	if rng.Exponential() < c*float64(counts[1]) {
+		goto retry
+	}
+
+	var partition = make([]int, 0, n)
+	for k := n; k > 0; k-- {
+		for i := 0; i < counts[k]; i++ {
+			partition = append(partition, k)
+		}
+	}
+	return partition
+}
+
+// Returns a uniformly random composition of n: a slice of positive ints
+// that sum to n where, unlike Partition(), the order of the parts matters
+//
+// Returns an empty slice if n <= 0
+func (rng *Gen) Composition(n int) []int {
+	if n <= 0 {
+		return []int{}
+	}
+	// Each of the n-1 gaps between n ones is independently
+	// either a cut or not, with 50% odds
+	const bitsInUint64 = 64
+	var composition = make([]int, 0, n/2+1)
+	var part = 1
+	var random uint64
+	for gap := 0; gap < n-1; gap++ {
+		if gap%bitsInUint64 == 0 {
+			random = rng.Uint64()
+		}
+		if random&1 == 1 {
+			composition = append(composition, part)
+			part = 0
+		}
+		random >>= 1
+		part++
+	}
+	return append(composition, part)
+}
+
+// Returns the number of failures before the first success in a sequence
+// of independent trials that each succeed with probability p
+//
+// Expects p to be in the interval (0.0, 1.0)
+func (rng *Gen) geometric(p float64) uint64 {
+	var count = math.Floor(rng.Exponential() / -math.Log1p(-p))
+	if count >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(count)
+}
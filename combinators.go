@@ -0,0 +1,184 @@
+package randshiro
+
+import (
+	"fmt"
+	"math"
+)
+
+// Returns a float64 distributed according to quantile, the inverse of its CDF
+//
+// quantile is only ever called on the open interval (0.0, 1.0),
+// so it doesn't need to handle the (usually infinite) endpoints
+func InverseCDF(rng *Gen, quantile func(p float64) float64) float64 {
+	return quantile(rng.Float64Open())
+}
+
+// Returns a float64 distributed according to density by rejection sampling
+//
+// proposal draws a candidate and returns it alongside the proposal's own density at
+// that candidate. bound must satisfy density(x) <= bound * proposalDensity(x) for every x,
+// and the expected number of candidates drawn is bound divided by the area under density,
+// so a tight bound matters. Neither density needs to be normalized. For example,
+// a half-normal from an exponential proposal:
+//
+//	var x = randshiro.Rejection(rng, func(rng *randshiro.Gen) (float64, float64) {
+//		var x = rng.Exponential()
+//		return x, math.Exp(-x)
+//	}, func(x float64) float64 {
+//		return math.Exp(-x * x / 2)
+//	}, math.Exp(0.5))
+func Rejection(rng *Gen, proposal func(rng *Gen) (x, proposalDensity float64), density func(x float64) float64, bound float64) float64 {
+	for {
+		var x, proposalDensity = proposal(rng)
+		if rng.Float64()*bound*proposalDensity < density(x) {
+			return x
+		}
+	}
+}
+
+// Samples an arbitrary density on an interval from a precomputed table
+//
+// The density is tabulated at evenly spaced points and treated as piecewise
+// linear between them; an alias table picks a bin in constant time and the bin's
+// linear density is then inverted exactly
+type Tabulated struct {
+	lowerBound float64
+	binWidth   float64
+	// Tabulated density at each of the bin edges
+	densities []float64
+	bins      aliasTable
+}
+
+// Returns a *Tabulated for density on the interval [lowerBound, upperBound],
+// tabulated at bins+1 evenly spaced points
+//
+// density doesn't need to be normalized, but it must be finite and non-negative
+// across the interval and not zero everywhere. More bins are more accurate for
+// curved densities; densities that are already piecewise linear over the bins are exact
+func NewTabulated(density func(x float64) float64, lowerBound, upperBound float64, bins int) (*Tabulated, error) {
+	const method = "NewTabulated"
+	if err := checkFinite(method, "lowerBound", lowerBound); err != nil {
+		return nil, err
+	}
+	if err := checkFinite(method, "upperBound", upperBound); err != nil {
+		return nil, err
+	}
+	if lowerBound >= upperBound {
+		return nil, argumentError(method, "upperBound", upperBound, fmt.Sprintf("must be greater than lowerBound (%v)", lowerBound))
+	}
+	if bins < 1 {
+		return nil, argumentError(method, "bins", bins, "must be at least 1")
+	}
+	var binWidth = (upperBound - lowerBound) / float64(bins)
+	var densities = make([]float64, bins+1)
+	for i := range densities {
+		var x = lowerBound + float64(i)*binWidth
+		if i == bins {
+			x = upperBound
+		}
+		densities[i] = density(x)
+		if err := checkNonNegative(method, fmt.Sprintf("density(%v)", x), densities[i]); err != nil {
+			return nil, err
+		}
+	}
+	// Trapezoid areas; the common factor of binWidth/2 doesn't affect the alias table
+	var masses = make([]float64, bins)
+	for i := range masses {
+		masses[i] = densities[i] + densities[i+1]
+	}
+	if err := checkWeights(method, "density", masses); err != nil {
+		return nil, err
+	}
+	return &Tabulated{
+		lowerBound: lowerBound,
+		binWidth:   binWidth,
+		densities:  densities,
+		bins:       newAliasTable(masses),
+	}, nil
+}
+
+// Returns a float64 in the tabulated interval distributed according to the tabulated density
+func (dist *Tabulated) Sample(rng *Gen) float64 {
+	var i = dist.bins.sample(rng)
+	var t = linearInversion(dist.densities[i], dist.densities[i+1], rng.Float64())
+	return dist.lowerBound + (float64(i)+t)*dist.binWidth
+}
+
+// Returns the t in the interval [0.0, 1.0] at which the CDF of the linear density
+// running from f0 at t = 0 to f1 at t = 1 reaches the fraction u of its total
+func linearInversion(f0, f1, u float64) float64 {
+	// Solves f0*t + (f1-f0)*t^2/2 = u*(f0+f1)/2 for t, written in the
+	// form that doesn't cancel catastrophically when f0 and f1 are close
+	var discriminant = f0*f0 + u*(f1*f1-f0*f0)
+	var t = u * (f0 + f1) / (f0 + math.Sqrt(math.Max(discriminant, 0)))
+	return math.Min(t, 1)
+}
+
+// Walker's alias method, built with Vose's algorithm:
+// picks an index in constant time with probability proportional to its weight
+type aliasTable struct {
+	// Odds of keeping each index rather than switching to its alias
+	probability []float64
+	alias       []int
+}
+
+// Expects weights to be non-negative with a positive sum
+func newAliasTable(weights []float64) aliasTable {
+	var n = len(weights)
+	var table = aliasTable{probability: make([]float64, n), alias: make([]int, n)}
+	var total float64
+	for _, weight := range weights {
+		total += weight
+	}
+	// Scaled so that the average weight is 1
+	var scaled = make([]float64, n)
+	var small, large = make([]int, 0, n), make([]int, 0, n)
+	var heaviest = 0
+	for i, weight := range weights {
+		if weight > weights[heaviest] {
+			heaviest = i
+		}
+		scaled[i] = weight * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	// Each small index is topped up to 1 with probability donated by a large one
+	for len(small) > 0 && len(large) > 0 {
+		var lesser = small[len(small)-1]
+		small = small[:len(small)-1]
+		var greater = large[len(large)-1]
+		table.probability[lesser] = scaled[lesser]
+		table.alias[lesser] = greater
+		scaled[greater] -= 1 - scaled[lesser]
+		if scaled[greater] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, greater)
+		}
+	}
+	// Whatever remains is (up to round-off) exactly 1
+	for _, i := range large {
+		table.probability[i] = 1
+		table.alias[i] = i
+	}
+	for _, i := range small {
+		// Round-off can strand a zero weight here, which must still never be picked
+		if weights[i] == 0 {
+			table.alias[i] = heaviest
+			continue
+		}
+		table.probability[i] = 1
+		table.alias[i] = i
+	}
+	return table
+}
+
+func (table aliasTable) sample(rng *Gen) int {
+	var i = rng.Intn(len(table.probability))
+	if rng.Float64() < table.probability[i] {
+		return i
+	}
+	return table.alias[i]
+}
@@ -0,0 +1,254 @@
+package randshiro
+
+import (
+	"fmt"
+	"math"
+)
+
+// A distribution that can be sampled with any *Gen
+//
+// Implementations are values describing a distribution rather than generators
+// themselves, so sampling never changes them.
+// *Tabulated also satisfies Distribution[float64]
+type Distribution[T any] interface {
+	Sample(rng *Gen) T
+}
+
+// Implemented by distributions that know their own mean and variance
+//
+// Either can be NaN when it is unknown (e.g. a wrapper around a distribution
+// that doesn't implement Moments) or undefined, and either can be +Inf
+type Moments interface {
+	Mean() float64
+	Variance() float64
+}
+
+// Types that support arithmetic and ordering,
+// used by the wrappers that shift, scale, or truncate samples
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Returns the mean and variance of dist, or NaNs if it doesn't implement Moments
+func moments[T any](dist Distribution[T]) (mean, variance float64) {
+	if m, ok := dist.(Moments); ok {
+		return m.Mean(), m.Variance()
+	}
+	return math.NaN(), math.NaN()
+}
+
+// A normal distribution with mean Mu and standard deviation Sigma
+type Normal struct {
+	Mu, Sigma float64
+}
+
+// Returns a normally distributed float64
+//
+// Only uses one of the pair returned by NormalDist();
+// call that directly when generating many samples
+func (dist Normal) Sample(rng *Gen) float64 {
+	var x, _ = rng.NormalDist(dist.Mu, dist.Sigma)
+	return x
+}
+
+// Returns Mu
+func (dist Normal) Mean() float64 { return dist.Mu }
+
+// Returns Sigma squared
+func (dist Normal) Variance() float64 { return dist.Sigma * dist.Sigma }
+
+// An exponential distribution with rate parameter Rate (the inverse of its mean)
+type Exponential struct {
+	Rate float64
+}
+
+// Returns an exponentially distributed float64
+func (dist Exponential) Sample(rng *Gen) float64 {
+	return rng.Exponential() / dist.Rate
+}
+
+// Returns 1/Rate
+func (dist Exponential) Mean() float64 { return 1 / dist.Rate }
+
+// Returns 1/Rate^2
+func (dist Exponential) Variance() float64 { return 1 / (dist.Rate * dist.Rate) }
+
+// A uniform distribution over the float64s in the interval [Lo, Hi)
+type Uniform struct {
+	Lo, Hi float64
+}
+
+// Returns a uniformly distributed float64 in the interval [Lo, Hi)
+func (dist Uniform) Sample(rng *Gen) float64 {
+	return rng.Float64Range(dist.Lo, dist.Hi)
+}
+
+// Returns the midpoint of Lo and Hi
+func (dist Uniform) Mean() float64 { return dist.Lo/2 + dist.Hi/2 }
+
+// Returns (Hi-Lo)^2 / 12
+func (dist Uniform) Variance() float64 {
+	var width = dist.Hi - dist.Lo
+	return width * width / 12
+}
+
+// A uniform distribution over the ints in the interval [Lo, Hi]
+//
+// Both ends are included, so UniformInt{1, 6} is a fair die
+type UniformInt struct {
+	Lo, Hi int
+}
+
+// Returns a uniformly distributed int in the interval [Lo, Hi]
+func (dist UniformInt) Sample(rng *Gen) int {
+	return rng.IntBetween(dist.Lo, dist.Hi)
+}
+
+// Returns the midpoint of Lo and Hi
+func (dist UniformInt) Mean() float64 { return float64(dist.Lo)/2 + float64(dist.Hi)/2 }
+
+// Returns (n^2-1) / 12, where n = Hi-Lo+1 is the number of possible values
+func (dist UniformInt) Variance() float64 {
+	var count = float64(dist.Hi) - float64(dist.Lo) + 1
+	return (count*count - 1) / 12
+}
+
+// A weighted mixture of distributions: each sample comes from one
+// component, chosen with probability proportional to its weight
+type Mixture[T any] struct {
+	components []Distribution[T]
+	weights    []float64
+	table      aliasTable
+}
+
+// Returns a *Mixture of components, weighted by weights
+//
+// weights must be the same length as components, finite and non-negative,
+// and have a positive sum. Picking a component takes constant time regardless
+// of how many there are
+func NewMixture[T any](components []Distribution[T], weights []float64) (*Mixture[T], error) {
+	const method = "NewMixture"
+	if len(weights) != len(components) {
+		return nil, argumentError(method, "len(weights)", len(weights), fmt.Sprintf("must equal len(components) (%d)", len(components)))
+	}
+	if err := checkWeights(method, "weights", weights); err != nil {
+		return nil, err
+	}
+	var mixture = &Mixture[T]{
+		components: make([]Distribution[T], len(components)),
+		weights:    make([]float64, len(weights)),
+		table:      newAliasTable(weights),
+	}
+	copy(mixture.components, components)
+	var total float64
+	for _, weight := range weights {
+		total += weight
+	}
+	for i, weight := range weights {
+		mixture.weights[i] = weight / total
+	}
+	return mixture, nil
+}
+
+// Returns a sample from a randomly chosen component
+func (dist *Mixture[T]) Sample(rng *Gen) T {
+	return dist.components[dist.table.sample(rng)].Sample(rng)
+}
+
+// NaN unless every component with a nonzero weight implements Moments
+func (dist *Mixture[T]) Mean() float64 {
+	var mean, _ = dist.moments()
+	return mean
+}
+
+// NaN unless every component with a nonzero weight implements Moments
+func (dist *Mixture[T]) Variance() float64 {
+	var _, variance = dist.moments()
+	return variance
+}
+
+// Law of total variance: the weighted second moments minus the squared mean
+func (dist *Mixture[T]) moments() (mean, variance float64) {
+	var secondMoment float64
+	for i, component := range dist.components {
+		if dist.weights[i] == 0 {
+			continue
+		}
+		var m, v = moments(component)
+		mean += dist.weights[i] * m
+		secondMoment += dist.weights[i] * (v + m*m)
+	}
+	return mean, math.Max(secondMoment-mean*mean, 0)
+}
+
+// Dist with Offset added to each sample
+type Shifted[T Number] struct {
+	Dist   Distribution[T]
+	Offset T
+}
+
+// Returns a sample from Dist plus Offset
+func (dist Shifted[T]) Sample(rng *Gen) T {
+	return dist.Dist.Sample(rng) + dist.Offset
+}
+
+// NaN unless Dist implements Moments
+func (dist Shifted[T]) Mean() float64 {
+	var mean, _ = moments(dist.Dist)
+	return mean + float64(dist.Offset)
+}
+
+// NaN unless Dist implements Moments
+func (dist Shifted[T]) Variance() float64 {
+	var _, variance = moments(dist.Dist)
+	return variance
+}
+
+// Dist with each sample multiplied by Factor
+type Scaled[T Number] struct {
+	Dist   Distribution[T]
+	Factor T
+}
+
+// Returns a sample from Dist multiplied by Factor
+func (dist Scaled[T]) Sample(rng *Gen) T {
+	return dist.Dist.Sample(rng) * dist.Factor
+}
+
+// NaN unless Dist implements Moments
+func (dist Scaled[T]) Mean() float64 {
+	var mean, _ = moments(dist.Dist)
+	return mean * float64(dist.Factor)
+}
+
+// NaN unless Dist implements Moments
+func (dist Scaled[T]) Variance() float64 {
+	var _, variance = moments(dist.Dist)
+	return variance * float64(dist.Factor) * float64(dist.Factor)
+}
+
+// Dist restricted to the interval [Lo, Hi]
+//
+// Samples outside of the interval are rerolled, so the expected number of
+// samples drawn from Dist is the inverse of the probability of landing inside it.
+// For a normal distribution truncated far out in a tail, use TruncatedNormal() instead.
+// Doesn't implement Moments, since truncation changes them in a way
+// that depends on the whole distribution
+type Truncated[T Number] struct {
+	Dist   Distribution[T]
+	Lo, Hi T
+}
+
+// Returns the first sample from Dist in the interval [Lo, Hi]
+//
+// Never returns if Dist can't produce a sample in the interval
+func (dist Truncated[T]) Sample(rng *Gen) T {
+	for {
+		var x = dist.Dist.Sample(rng)
+		if x >= dist.Lo && x <= dist.Hi {
+			return x
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package randshiro
+
+import "math"
+
+// Returns a log-normally distributed float64: the exponential of a normally
+// distributed float64 with mean mu and stddev sigma
+//
+// Makes no range checks on mu/sigma
+func (rng *Gen) Lognormal(mu, sigma float64) float64 {
+	var x, _ = rng.Normal()
+	return math.Exp(mu + sigma*x)
+}
+
+// Returns a Weibull distributed float64 with shape k and scale lambda
+//
+// Makes no range checks on k/lambda
+func (rng *Gen) Weibull(k, lambda float64) float64 {
+	// Inverse CDF: lambda * (-log(U))^(1/k)
+	return lambda * math.Pow(rng.Exponential(), 1/k)
+}
+
+// Returns a Pareto distributed float64 with scale (minimum value) xm
+// and shape (tail index) alpha
+//
+// Makes no range checks on xm/alpha
+func (rng *Gen) Pareto(xm, alpha float64) float64 {
+	// Inverse CDF: xm * U^(-1/alpha), where -log(U) is Exponential()
+	return xm * math.Exp(rng.Exponential()/alpha)
+}
+
+// Returns a Cauchy distributed float64 with location x0 and scale gamma
+//
+// Makes no range checks on x0/gamma
+func (rng *Gen) Cauchy(x0, gamma float64) float64 {
+	// Inverse CDF; Float64Open() keeps tan() away from its poles
+	return x0 + gamma*math.Tan(math.Pi*(rng.Float64Open()-0.5))
+}
+
+// Returns a Laplace (double exponential) distributed float64
+// with location mu and scale b
+//
+// Makes no range checks on mu/b
+func (rng *Gen) Laplace(mu, b float64) float64 {
+	// Inverse CDF, with u in the interval (-0.5, 0.5)
+	var u = rng.Float64Open() - 0.5
+	if u < 0 {
+		return mu + b*math.Log1p(2*u)
+	}
+	return mu - b*math.Log1p(-2*u)
+}
+
+// Returns a logistically distributed float64 with location mu and scale s
+//
+// Makes no range checks on mu/s
+func (rng *Gen) Logistic(mu, s float64) float64 {
+	// Inverse CDF: mu + s*log(u/(1-u))
+	var u = rng.Float64Open()
+	return mu + s*(math.Log(u)-math.Log1p(-u))
+}
+
+// Returns a Gumbel (type I extreme value) distributed float64
+// with location mu and scale beta
+//
+// Makes no range checks on mu/beta
+func (rng *Gen) Gumbel(mu, beta float64) float64 {
+	// Inverse CDF: mu - beta*log(-log(U)); U can't be 1.0
+	// since -log(1.0) would send the result to infinity
+	return mu - beta*math.Log(-math.Log(rng.Float64Open()))
+}
+
+// Returns a gamma distributed float64 with shape k and scale theta
+//
+// Makes no range checks on k/theta
+func (rng *Gen) Gamma(k, theta float64) float64 {
+	if k < 1 {
+		return math.Exp(rng.logGamma(k) + math.Log(theta))
+	}
+	// Marsaglia and Tsang's method:
+	// https://dl.acm.org/doi/10.1145/358407.358414
+	var d = k - 1.0/3
+	var c = 1 / math.Sqrt(9*d)
+	for {
+		// Normal() returns pairs, so each call gives two attempts
+		var x1, x2 = rng.Normal()
+		for _, x := range [2]float64{x1, x2} {
+			var v = 1 + c*x
+			if v <= 0 {
+				continue
+			}
+			v = v * v * v
+			var u = rng.Float64Open()
+			var xx = x * x
+			// Cheap squeeze that accepts most attempts without a logarithm
+			if u < 1-0.0331*xx*xx || math.Log(u) < 0.5*xx+d*(1-v+math.Log(v)) {
+				return d * v * theta
+			}
+		}
+	}
+}
+
+// Returns the logarithm of a gamma distributed float64 with shape k and scale 1
+//
+// Stays finite for small shapes, whose gamma variates often underflow to 0
+func (rng *Gen) logGamma(k float64) float64 {
+	if k < 1 {
+		// Boost the shape above 1 and correct for it with
+		// Gamma(k) = Gamma(k+1) * U^(1/k), where -log(U) is Exponential()
+		return math.Log(rng.Gamma(k+1, 1)) - rng.Exponential()/k
+	}
+	return math.Log(rng.Gamma(k, 1))
+}
+
+// Returns a chi-squared distributed float64 with k degrees of freedom
+//
+// Makes no range checks on k
+func (rng *Gen) ChiSquared(k float64) float64 {
+	return rng.Gamma(k/2, 2)
+}
+
+// Returns a Student's t distributed float64 with nu degrees of freedom
+//
+// Makes no range checks on nu
+func (rng *Gen) StudentT(nu float64) float64 {
+	var z, _ = rng.Normal()
+	return z / math.Sqrt(rng.ChiSquared(nu)/nu)
+}
+
+// Returns an F distributed float64 with d1 and d2 degrees of freedom
+//
+// Makes no range checks on d1/d2
+func (rng *Gen) FDist(d1, d2 float64) float64 {
+	return (rng.ChiSquared(d1) / d1) / (rng.ChiSquared(d2) / d2)
+}
+
+// Returns a noncentral chi-squared distributed float64 with k degrees
+// of freedom and noncentrality parameter lambda
+//
+// Makes no range checks on k/lambda
+func (rng *Gen) NoncentralChiSquared(k, lambda float64) float64 {
+	if lambda == 0 {
+		return rng.ChiSquared(k)
+	}
+	if k > 1 {
+		// The sum of a squared normal with mean sqrt(lambda)
+		// and an independent (central) chi-squared with k-1 degrees of freedom
+		var z, _ = rng.Normal()
+		z += math.Sqrt(lambda)
+		return z*z + rng.ChiSquared(k-1)
+	}
+	// A Poisson mixture of central chi-squareds
+	return rng.ChiSquared(k + 2*float64(rng.Poisson(lambda/2)))
+}
+
+// Returns a Poisson distributed int with the given mean
+//
+// Makes no range checks on mean
+func (rng *Gen) Poisson(mean float64) int {
+	if mean < 10 {
+		// Knuth's multiplication method: count how many uniforms can be
+		// multiplied together before the product drops below exp(-mean)
+		var limit = math.Exp(-mean)
+		var count = 0
+		for product := rng.Float64OpenClosed(); product > limit; product *= rng.Float64OpenClosed() {
+			count++
+		}
+		return count
+	}
+	// Hörmann's transformed rejection with squeeze (PTRS):
+	// https://doi.org/10.1016/0167-6687(93)90997-4
+	var (
+		logMean         = math.Log(mean)
+		b               = 0.931 + 2.53*math.Sqrt(mean)
+		a               = -0.059 + 0.02483*b
+		logInverseAlpha = math.Log(1.1239 + 1.1328/(b-3.4))
+		acceptThreshold = 0.9277 - 3.6224/(b-2)
+	)
+	for {
+		var u = rng.Float64Open() - 0.5
+		var v = rng.Float64Open()
+		var us = 0.5 - math.Abs(u)
+		var k = math.Floor((2*a/us+b)*u + mean + 0.43)
+		if us >= 0.07 && v <= acceptThreshold {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		var logFactorial, _ = math.Lgamma(k + 1)
+		if math.Log(v)+logInverseAlpha-math.Log(a/(us*us)+b) <= -mean+k*logMean-logFactorial {
+			return int(k)
+		}
+	}
+}
+
+// Returns a normally distributed float64 with user-defined mean and stddev,
+// truncated to the interval [lowerBound, upperBound]
+//
+// Either bound may be infinite. Unlike rerolling NormalDist() until the result
+// lands in range, this stays fast when the interval is far out in a tail.
+// Makes no range checks on mean/stddev/lowerBound/upperBound
+func (rng *Gen) TruncatedNormal(mean, stddev, lowerBound, upperBound float64) float64 {
+	var a = (lowerBound - mean) / stddev
+	var b = (upperBound - mean) / stddev
+	var z float64
+	if b <= 0 {
+		// Mirror the left tail onto the right one
+		z = -rng.truncatedStandardNormal(-b, -a)
+	} else {
+		z = rng.truncatedStandardNormal(a, b)
+	}
+	// Rounding can push the result just outside of the interval
+	return math.Min(math.Max(mean+stddev*z, lowerBound), upperBound)
+}
+
+// Returns a standard normal float64 truncated to the interval [a, b], where b > 0
+//
+// Uses Robert's accept-reject methods, choosing whichever proposal
+// accepts most often: https://arxiv.org/abs/0907.4010
+func (rng *Gen) truncatedStandardNormal(a, b float64) float64 {
+	if a == b {
+		return a
+	}
+	const sqrtTwoPi = 2.5066282746310002
+	if a < 0 {
+		if b-a >= sqrtTwoPi {
+			// The interval covers enough of the bulk that plain
+			// rejection accepts at least as often as the alternative
+			for {
+				var x, y = rng.Normal()
+				if a <= x && x <= b {
+					return x
+				}
+				if a <= y && y <= b {
+					return y
+				}
+			}
+		}
+		// Uniform proposal against the density's peak at zero
+		for {
+			var z = rng.Float64Range(a, b)
+			if rng.Exponential() >= z*z/2 {
+				return z
+			}
+		}
+	}
+
+	// The whole interval is in the right tail, so the density peaks at a
+	var alpha = (a + math.Sqrt(a*a+4)) / 2
+	if b-a < math.Exp((a*a-a*math.Sqrt(a*a+4))/4+0.5)/alpha {
+		// Narrow interval: uniform proposal against the density at a
+		for {
+			var z = rng.Float64Range(a, b)
+			if rng.Exponential() >= (z*z-a*a)/2 {
+				return z
+			}
+		}
+	}
+	// Translated exponential proposal with the optimal rate alpha
+	for {
+		var z = a + rng.Exponential()/alpha
+		if z > b {
+			continue
+		}
+		var d = z - alpha
+		if rng.Exponential() >= d*d/2 {
+			return z
+		}
+	}
+}
+
+// Returns an exponentially distributed float64 with rate constant lambda,
+// truncated to the interval [0.0, upperBound)
+//
+// upperBound may be infinite. Uses exact inversion of the truncated CDF.
+// Makes no range checks on lambda/upperBound
+func (rng *Gen) TruncatedExponential(lambda, upperBound float64) float64 {
+	// Probability mass of the untruncated distribution that lies below upperBound
+	var mass = -math.Expm1(-lambda * upperBound)
+	var x = -math.Log1p(-rng.Float64()*mass) / lambda
+	// Rounding can land exactly on upperBound
+	if x >= upperBound {
+		return math.Nextafter(upperBound, 0)
+	}
+	return x
+}
+
+// Returns a Yule–Simon distributed uint64 in the interval [1, 2^64) with shape rho,
+// a discrete power law whose tail falls off as k^-(rho+1)
+//
+// Makes no range checks on rho
+func (rng *Gen) YuleSimon(rho float64) uint64 {
+	// A geometric distribution whose success probability is exp(-W)
+	// for an exponentially distributed W with rate rho
+	var p = math.Exp(-rng.Exponential() / rho)
+	if p >= 1 {
+		return 1
+	}
+	var failures = rng.geometric(p)
+	if failures == math.MaxUint64 {
+		return failures
+	}
+	return failures + 1
+}
+
+// Returns a beta distributed float64 in the interval [0.0, 1.0]
+// with shape parameters a and b
+//
+// Makes no range checks on a/b
+func (rng *Gen) Beta(a, b float64) float64 {
+	if a >= 1 && b >= 1 {
+		var x = rng.Gamma(a, 1)
+		var y = rng.Gamma(b, 1)
+		return x / (x + y)
+	}
+	// Small shapes can underflow both gamma variates to 0,
+	// so x/(x+y) is taken from their logarithms instead
+	var logX = rng.logGamma(a)
+	var logY = rng.logGamma(b)
+	return 1 / (1 + math.Exp(logY-logX))
+}
+
+// Returns a binomially distributed int: the number of successes
+// in n independent trials that each succeed with probability p
+//
+// Runs in time proportional to log(n) rather than n.
+// Makes no range checks on n/p
+func (rng *Gen) Binomial(n int, p float64) int {
+	// Below this mean, inversion is cheaper than another halving step
+	const inversionThreshold = 16
+	var count = 0
+	// Knuth's recursive halving (TAOCP vol. 2, 3.4.1): the (n/2)th smallest
+	// of n uniforms is beta distributed, and conditioned on where it lands
+	// the uniforms on either side of it are binomial trials with adjusted odds
+	for float64(n)*math.Min(p, 1-p) >= inversionThreshold {
+		var a = 1 + n/2
+		var b = n + 1 - a
+		var x = rng.Beta(float64(a), float64(b))
+		if x >= p {
+			n = a - 1
+			p /= x
+		} else {
+			count += a
+			n = b - 1
+			p = (p - x) / (1 - x)
+		}
+	}
+	return count + rng.binomialInversion(n, p)
+}
+
+// Returns a binomially distributed int by sequential search of its CDF,
+// which takes time proportional to n*min(p, 1-p)
+func (rng *Gen) binomialInversion(n int, p float64) int {
+	if p > 0.5 {
+		return n - rng.binomialInversion(n, 1-p)
+	}
+	if p <= 0 || n <= 0 {
+		return 0
+	}
+	var q = 1 - p
+	var ratio = p / q
+	// Probability of zero successes, then updated with
+	// pmf(k) = pmf(k-1) * (n-k+1)/k * p/q
+	var pmf = math.Pow(q, float64(n))
+	var u = rng.Float64()
+	var k = 0
+	for u > pmf && k < n {
+		u -= pmf
+		k++
+		pmf *= float64(n-k+1) / float64(k) * ratio
+	}
+	return k
+}
+
+// Returns a Dirichlet distributed vector with concentration parameters alpha:
+// len(alpha) non-negative float64s that sum to 1
+//
+// Makes no range checks on alpha
+func (rng *Gen) Dirichlet(alpha []float64) []float64 {
+	var vector = make([]float64, len(alpha))
+	var largest = math.Inf(-1)
+	for i, a := range alpha {
+		vector[i] = rng.logGamma(a)
+		largest = math.Max(largest, vector[i])
+	}
+	// Log-sum-exp: scaling by the largest gamma variate before leaving log space
+	// keeps small shapes, whose gamma variates can all underflow to 0, from
+	// leaving nothing to normalize
+	var sum float64
+	for i := range vector {
+		vector[i] = math.Exp(vector[i] - largest)
+		sum += vector[i]
+	}
+	for i := range vector {
+		vector[i] /= sum
+	}
+	return vector
+}
+
+// Returns multinomially distributed counts: how many of n independent trials
+// landed in each category, where category i is chosen with probability p[i]
+//
+// p doesn't need to be normalized. Runs in time proportional to
+// len(p)*log(n) by drawing each count from a binomial conditioned on the
+// counts before it, rather than simulating each trial.
+// Makes no range checks on n/p
+func (rng *Gen) Multinomial(n int, p []float64) []int {
+	var counts = make([]int, len(p))
+	var remainingMass float64
+	for _, x := range p {
+		remainingMass += x
+	}
+	for i, x := range p {
+		if n == 0 {
+			break
+		}
+		if i == len(p)-1 || x >= remainingMass {
+			counts[i] = n
+			break
+		}
+		counts[i] = rng.Binomial(n, x/remainingMass)
+		n -= counts[i]
+		remainingMass -= x
+	}
+	return counts
+}
@@ -32,6 +32,8 @@ which internally manages one of the backing generators.
 That being said, if you currently use math/rand and your code only calls Intn(), Float32(),
 and/or Float64(), the only change you'll need to make is replacing your math/rand instantiations
 with one of the randshiro factory functions.
+The math/rand integer methods Int(), Int63(), Int31(), Int63n(), Int31n(), and Uint32()
+are all present as well, alongside the width-specific Int64n(), Int32n(), and Uint32n().
 
 Local initialization of a math/rand instance may look like:
 
@@ -46,11 +48,34 @@ But using randshiro:
 
 New() is a convenience wrapper for New256pp().
 
-Unlike math/rand, randshiro does not provide a global generator instance.
-The only reason I can think of for math/rand having this is the high cost
-of creating and maintaining instances. Since randshiro generators are very cheap to
-create and maintain it is instead recommended to create a unique
-Gen instance for each function or goroutine that needs one.
+Since randshiro generators are very cheap to create and maintain it is recommended to create
+a unique Gen instance for each function or goroutine that needs one, as a *Gen is not threadsafe.
+The distribution types built by New...() functions (*MultivariateNormal, *Polygon, *Tabulated,
+*Mixture, *Empirical, *KDE, *PiecewiseConstant, and *PiecewiseLinear) are the opposite: they never
+change after creation and take the *Gen to draw from as an argument, so a single instance can be
+shared between any number of goroutines, as long as each of them samples it with its own *Gen.
+For code where that is awkward, randshiro also provides package-level functions named after
+their math/rand counterparts (Intn(), Float64(), NormFloat64(), ExpFloat64(), Perm(), ...).
+Unlike the math/rand globals they aren't backed by a single locked generator: each call borrows
+a *Gen from a sync.Pool, which keeps a cache per P, so goroutines calling them in parallel
+almost never contend with each other. A *LockedGen from NewLockedGen() has every method of *Gen
+guarded by a mutex, for the rare case where a single seeded stream has to be shared;
+every call contends on the same lock, so it is the slowest of the three options.
+BenchmarkUint64Parallel, BenchmarkLockedUint64Parallel, and BenchmarkLocalUint64Parallel
+measure them against BenchmarkMathRandUint64Parallel (the global math/rand instance).
+
+For Monte Carlo work that has to be reproducible, RunParallel() spreads tasks across
+GOMAXPROCS goroutines and seeds the *Gen handed to each task from a root seed and the task's index,
+so results are identical no matter how the tasks happen to be scheduled:
+
+	var results = make([]float64, trials)
+	var err = randshiro.RunParallel(ctx, rootSeed, trials, func(i int, rng *randshiro.Gen) error {
+		results[i] = simulate(rng)
+		return nil
+	})
+
+A failing task stops new tasks from starting, and the error from the lowest failing index is
+returned as a *TaskError. TaskSeed() returns the seed of a single task, so it can be rerun on its own.
 
 If a *Gen needs to be manually seeded, there is a ManualSeed()
 
@@ -65,6 +90,14 @@ Since backing generator instances live behind an interface, it is not required t
 to re-seed is the same that was originally used for that *Gen.
 
 Methods belonging to Gen generally do no range verification of the variables passed into them.
+If arguments come from somewhere you don't control, wrap the *Gen with NewChecked().
+The returned Checked has the same methods, but those that could panic or return
+nonsense on bad input instead validate their arguments and return an *ArgumentError:
+
+	var rng = randshiro.NewChecked(randshiro.New())
+	if x, err := rng.Intn(userBound); err != nil {
+		// err matches randshiro.ErrInvalidArgument
+	}
 
 # Performance
 
@@ -120,6 +153,11 @@ All benchmarks for math/rand (with the exception of Uint64Parallel) are for loca
 Using the global instance in parallel goroutines will completely decimate performance. If you're
 currently doing so, moving to randshiro will likely result in orders of magnitude better performance.
 
+That only holds when the goroutines actually run in parallel. With a single CPU the global
+instance's lock is never contended, and since borrowing a *Gen from the pool costs more than
+taking an uncontended lock, randshiro's package-level functions can end up slower than math/rand's.
+Code that calls them in a hot loop should use a local *Gen either way.
+
 Float64() and Float32() are both extremely fast due to being just a shift and multiplication,
 and both are capable of uniformly generating all unique real numbers their type can accurately represent
 in the interval [0.0, 1.0). That is, multiplying their output by 2^53 for float64 or 2^24 for float32
@@ -130,14 +168,27 @@ If you need to batch-generate float32s, FastFloat32() provides two float32s for
 than the cost of one Float32() call.
 There is no speed difference when comparing Float64() vs. Float32();
 if you need float64s use Float64() and if you need float32s use Float32()/FastFloat32().
+Float64Open(), Float64OpenClosed(), and Float64Closed() (and their float32 counterparts)
+cover the (0.0, 1.0), (0.0, 1.0], and [0.0, 1.0] intervals, while Float64Range() and Float32Range()
+scale to an arbitrary [lowerBound, upperBound) and reroll the rare results that round up to upperBound.
 Explanation of the method used can be found at:
 https://lemire.me/blog/2017/02/28/how-many-floating-point-numbers-are-in-the-interval-01/
 
+The flip side is that Float64() never returns anything below 2^-53, nor most of the
+representable float64s near zero. When those matter (tail probabilities, for example)
+FullFloat64() and FullFloat32() can return every representable float in [0.0, 1.0),
+each with probability equal to its distance to the next representable float.
+The exponent is picked geometrically from the leading zeros of the generator's output,
+and since one draw is almost always enough they are only slightly slower than Float64()/Float32().
+
 IntnWorstCase calls it's Intn() with math.IntMax as the bound (and Intn() just wraps Uint64n()).
 Intn()/Uint64n() execution time should never exceed this time for *Gen instances backed by Xoshiro256++,
 but should be much closer to that of the 256ppIntn benchmark (bound of 1,000,000) for reasonable bounds.
 Generally speaking, Intn()/Uint64n() execution time increases the closer the bound is to math.IntMax.
 If you need a bound that happens to be a power of two prefer using Uint64bits().
+Uint32n() only needs 32 bit division on its rare slow path, and FastUint32n() returns
+two bounded uint32s from a single call to the backing generator, in the same way
+FastFloat32() does for float32s.
 
 Normal() is provided for generating normally distributed float64s.
 It uses the Marsaglia polar method (https://en.wikipedia.org/wiki/Marsaglia_polar_method)
@@ -148,6 +199,10 @@ Exponential() is provided for generating exponentially distributed float64s.
 It uses random variate generation to generate its output:
 https://en.wikipedia.org/wiki/Exponential_distribution#Random_variate_generation.
 
+NormalFloat32() and ExponentialFloat32() are their float32 counterparts, for code that works
+entirely in float32 and would otherwise have to convert. NormalFloat32() takes both
+coordinates of each polar method attempt from a single 50 bit roll, similar to FastFloat32().
+
 Other members of the Xoroshiro/Xoshiro PRNG family (+ and ** variants) were not included
 in this package because testing showed near zero performance benefit for doing so.
 In C/C++ they make more of a difference since a 0.14 ns jump is relatively big when your
@@ -157,9 +212,129 @@ so the jump is less meaningful. The jump is also smaller: when testing the 256+
 I was only seeing ~0.08 ns faster test results vs 256++ (~3.8 ns -> ~3.72 ns for Intn()).
 No Xoroshiro1024 variants were included because there is no use for them in this package.
 
+# Distributions
+
+Lognormal(), Weibull(), Pareto(), Cauchy(), Laplace(), Logistic(), and Gumbel() cover the
+common continuous distributions used for reliability and tail modelling. All of them are
+built on inverse CDFs or simple transformations of Normal(), Exponential(), and Float64Open().
+Like the rest of *Gen they make no range checks on their parameters; Checked validates them.
+
+Gamma() uses Marsaglia and Tsang's method (https://dl.acm.org/doi/10.1145/358407.358414),
+and ChiSquared(), StudentT(), FDist(), and NoncentralChiSquared() are composed from it and Normal(),
+so Monte Carlo experiments can draw every statistic they need from one seeded stream.
+Poisson() uses Knuth's multiplication method for small means and Hörmann's
+transformed rejection (PTRS) for large ones.
+
+TruncatedNormal() samples a normal distribution restricted to an interval using Robert's
+exponential and uniform proposals (https://arxiv.org/abs/0907.4010), so it stays fast even
+when the interval is many standard deviations out in a tail, where rerolling NormalDist()
+until the result lands in range would take practically forever.
+TruncatedExponential() inverts the truncated CDF exactly.
+
+Correlated normal vectors come from a *MultivariateNormal, created once from a mean vector
+and covariance matrix with NewMultivariateNormal() and then sampled with any *Gen:
+
+	var dist, err = randshiro.NewMultivariateNormal(mean, covariance)
+	var sample = dist.Sample(rng, make([]float64, dist.Dimension()))
+
+Positive semi-definite (but not definite) covariance matrices are handled with an
+LDL decomposition when the Cholesky decomposition fails.
+
+Beta() and Dirichlet() are built on Gamma(), and work with the logarithms of its output for
+shapes below 1, where the gamma variates themselves often underflow to 0. Binomial() uses Knuth's
+recursive halving on beta distributed order statistics, so it takes time proportional to log(n)
+instead of n, and Multinomial() draws each count from a binomial conditioned on the counts before it.
+
+For geometry, UnitSphere()/UnitBall() fill a slice with a uniformly distributed point on/in the
+unit sphere/ball of any dimension, with fixed-size fast paths UnitCircle()/UnitSphere3() and
+UnitDisk()/UnitBall3() that avoid allocating and avoid calling Normal(). Annulus(), Simplex(), and Triangle()
+cover rings, probability simplices, and triangles, while NewPolygon() triangulates a simple polygon
+once so that its Sample() method can pick a triangle weighted by area and sample inside it.
+
+UniformQuaternion() returns a uniformly random 3D rotation using Shoemake's method, and
+RandomOrthogonal() returns a Haar distributed orthogonal matrix of any size from the QR
+decomposition of a matrix of normals (with the sign correction that makes it actually uniform).
+
+NewZipf() returns a *Zipf bound to a *Gen, similar to the one from math/rand, but it uses Hörmann
+and Derflinger's rejection-inversion method and supports exponents at or below 1.
+YuleSimon() provides a discrete power law with an unbounded support.
+
+VonMises(), WrappedCauchy(), and WrappedNormal() return angles in the interval [-pi, pi)
+for circular data such as directions or phases. VonMises() uses Best and Fisher's method,
+and handles both a concentration near zero (uniform angles) and very large concentrations
+(the normal approximation) without losing precision.
+
 # Extra
 
 A Fisher-Yates shuffle is also provided as Shuffle(), but it is a function belonging to the randshiro package
 instead of a method belonging to *Gen. This is done to work around the inability to use generics in methods.
+
+For combinatorial work there is Combination() for drawing sorted k-combinations,
+Subset() for including each element independently with some probability (it skips
+geometrically distributed gaps, so it only does work proportional to the size of the subset),
+and Partition()/Composition() for uniformly random integer partitions and compositions.
+
+Permutations and combinations can be converted to and from their lexicographic rank with
+PermRank()/PermUnrank() and CombinationRank()/CombinationUnrank(), which lets a random
+permutation be stored as a single number and regenerated later:
+
+	var rng = randshiro.New()
+	var rank = rng.Uint64n(randshiro.PermCount(10))
+	var perm = randshiro.PermUnrank(10, rank)
+
+The uint64 variants only work while the number of objects fits in a uint64
+(n <= 20 for permutations); past that use the *big.Int variants ending in Big,
+and draw the rank with BigIntn():
+
+	var rank = rng.BigIntn(randshiro.PermCountBig(52))
+	var deck = randshiro.PermUnrankBig(52, rank)
+
+For integers that need more than 64 bits but fewer than a *big.Int,
+Uint128() and Uint128n() return the high and low halves of a uint128.
+Uint128n() uses the same nearly divisionless method as Uint64n(), widened to 128 bits.
+
+For distributions the package doesn't provide, InverseCDF() and Rejection() turn a quantile
+function or a density (plus a proposal that bounds it) into a sampler driven by a *Gen.
+When neither is available in closed form, NewTabulated() tabulates a density on an interval once,
+and its Sample() method picks a bin with Walker's alias method and inverts the density within it:
+
+	var dist, err = randshiro.NewTabulated(func(x float64) float64 {
+		return math.Exp(-x*x/2) * (1 + math.Sin(3*x))
+	}, -6, 6, 4096)
+	var x = dist.Sample(rng)
+
+Distributions can also be described as values implementing Distribution[T], whose Sample()
+method takes the *Gen to draw from. Normal, Exponential, Uniform, and UniformInt are plain structs,
+and Mixture, Shifted, Scaled, and Truncated wrap other distributions, so a simulation config
+can be built up declaratively:
+
+	var latency, err = randshiro.NewMixture([]randshiro.Distribution[float64]{
+		randshiro.Normal{Mu: 20, Sigma: 3},
+		randshiro.Shifted[float64]{Dist: randshiro.Exponential{Rate: 0.01}, Offset: 50},
+	}, []float64{0.95, 0.05})
+	var x = latency.Sample(rng)
+
+Distributions that know their mean and variance also implement Moments,
+and the wrappers derive theirs from the distributions they wrap (NaN when unknown).
+
+Specs from config files can be turned into samplers bound to a *Gen with ParseFloat64(),
+which understands normal(mu, sigma), exp(rate), uniform(lo, hi), and choice(x:weight, ...),
+and ParseString(), which understands choice(label:weight, ...). Choices are made with an alias table,
+String() returns the spec in canonical form, and a malformed spec returns a *ParseError
+pointing at the offending token:
+
+	var sampler, err = randshiro.ParseString(rng, "choice(read:9, write:1)")
+	var op = sampler.Sample()
+
+Measured data can be resampled directly: NewEmpirical() returns an *Empirical that picks one of
+the observations, optionally interpolating linearly between neighbouring quantiles, and NewKDE()
+returns a *KDE that adds normally distributed noise to each picked observation.
+SilvermanBandwidth() picks a reasonable amount of noise for unimodal data:
+
+	var kde, err = randshiro.NewKDE(latencies, randshiro.SilvermanBandwidth(latencies))
+
+NewPiecewiseConstant() and NewPiecewiseLinear() mirror C++'s piecewise_constant_distribution and
+piecewise_linear_distribution: the density is given at (or between) a sorted list of boundaries,
+a segment is picked with an alias table, and the density within it is inverted exactly.
 */
 package randshiro
@@ -0,0 +1,191 @@
+package randshiro
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Samples from a set of observed values
+//
+// Without interpolation each observation is returned with equal probability.
+// With interpolation the quantile function is instead linear between consecutive
+// sorted observations, so samples are spread continuously between the smallest
+// and largest observation
+type Empirical struct {
+	sorted      []float64
+	interpolate bool
+}
+
+// Returns an *Empirical built from a copy of observations, which must be non-empty and finite
+func NewEmpirical(observations []float64, interpolate bool) (*Empirical, error) {
+	var sorted, err = sortedObservations("NewEmpirical", observations)
+	if err != nil {
+		return nil, err
+	}
+	return &Empirical{sorted: sorted, interpolate: interpolate}, nil
+}
+
+// Returns one of the observations, or a value interpolated between two of them
+func (dist *Empirical) Sample(rng *Gen) float64 {
+	var n = len(dist.sorted)
+	if !dist.interpolate {
+		return dist.sorted[rng.Intn(n)]
+	}
+	if n == 1 {
+		return dist.sorted[0]
+	}
+	var position = rng.Float64() * float64(n-1)
+	var i = int(position)
+	// The multiplication can round up to n-1 when there are enough observations
+	if i >= n-1 {
+		return dist.sorted[n-1]
+	}
+	var fraction = position - float64(i)
+	return dist.sorted[i] + fraction*(dist.sorted[i+1]-dist.sorted[i])
+}
+
+// Returns the mean of the distribution being sampled, which is
+// the mean of the observations unless interpolating
+func (dist *Empirical) Mean() float64 {
+	var mean, _ = dist.moments()
+	return mean
+}
+
+// Returns the variance of the distribution being sampled, which is
+// the (population) variance of the observations unless interpolating
+func (dist *Empirical) Variance() float64 {
+	var _, variance = dist.moments()
+	return variance
+}
+
+func (dist *Empirical) moments() (mean, variance float64) {
+	var n = len(dist.sorted)
+	if !dist.interpolate || n == 1 {
+		return meanAndVariance(dist.sorted)
+	}
+	// An equally weighted mixture of uniform distributions, one between each pair of
+	// neighbours, with the moments taken relative to the midpoint for accuracy
+	var center = dist.sorted[0]/2 + dist.sorted[n-1]/2
+	var sum, sumSquares float64
+	for i := 0; i+1 < n; i++ {
+		var a, b = dist.sorted[i] - center, dist.sorted[i+1] - center
+		sum += (a + b) / 2
+		sumSquares += (a*a + a*b + b*b) / 3
+	}
+	var shiftedMean = sum / float64(n-1)
+	return center + shiftedMean, math.Max(sumSquares/float64(n-1)-shiftedMean*shiftedMean, 0)
+}
+
+// Samples a kernel density estimate with a normal kernel:
+// picks an observation uniformly and adds normally distributed noise
+type KDE struct {
+	observations []float64
+	bandwidth    float64
+}
+
+// Returns a *KDE built from a copy of observations, which must be non-empty and finite
+//
+// bandwidth is the standard deviation of the noise, and must be finite and not negative.
+// SilvermanBandwidth() gives a reasonable default for unimodal data
+func NewKDE(observations []float64, bandwidth float64) (*KDE, error) {
+	var sorted, err = sortedObservations("NewKDE", observations)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkNonNegative("NewKDE", "bandwidth", bandwidth); err != nil {
+		return nil, err
+	}
+	return &KDE{observations: sorted, bandwidth: bandwidth}, nil
+}
+
+// Returns a random observation plus normally distributed noise
+//
+// Only uses one of the pair returned by Normal()
+func (dist *KDE) Sample(rng *Gen) float64 {
+	var x = dist.observations[rng.Intn(len(dist.observations))]
+	var noise, _ = rng.Normal()
+	return x + dist.bandwidth*noise
+}
+
+// Returns the bandwidth the *KDE was created with
+func (dist *KDE) Bandwidth() float64 {
+	return dist.bandwidth
+}
+
+// Returns the mean of the observations, since the noise has a mean of 0
+func (dist *KDE) Mean() float64 {
+	var mean, _ = meanAndVariance(dist.observations)
+	return mean
+}
+
+// Returns the (population) variance of the observations plus bandwidth^2,
+// the variance of the noise added to each of them
+func (dist *KDE) Variance() float64 {
+	var _, variance = meanAndVariance(dist.observations)
+	return variance + dist.bandwidth*dist.bandwidth
+}
+
+// Returns Silverman's rule of thumb bandwidth for a normal kernel,
+// 0.9 * min(stddev, IQR/1.34) * n^(-1/5)
+//
+// Falls back to the standard deviation alone when the IQR is 0.
+// Returns 0 when there are fewer than two observations or they are all equal,
+// and NaN when any observation isn't finite
+func SilvermanBandwidth(observations []float64) float64 {
+	var n = len(observations)
+	if n < 2 {
+		return 0
+	}
+	var sorted = make([]float64, n)
+	copy(sorted, observations)
+	sort.Float64s(sorted)
+	var _, variance = meanAndVariance(sorted)
+	// Bessel's correction, since this is an estimate of the underlying distribution
+	var stddev = math.Sqrt(variance * float64(n) / float64(n-1))
+	var spread = stddev
+	if iqr := quantileSorted(sorted, 0.75) - quantileSorted(sorted, 0.25); iqr > 0 {
+		spread = math.Min(spread, iqr/1.34)
+	}
+	return 0.9 * spread * math.Pow(float64(n), -0.2)
+}
+
+// Returns the p-th quantile of sorted, interpolating linearly between observations
+func quantileSorted(sorted []float64, p float64) float64 {
+	var position = p * float64(len(sorted)-1)
+	var i = int(position)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	var fraction = position - float64(i)
+	return sorted[i] + fraction*(sorted[i+1]-sorted[i])
+}
+
+// Returns the mean and (population) variance of values, which must be non-empty
+func meanAndVariance(values []float64) (mean, variance float64) {
+	for _, x := range values {
+		mean += x
+	}
+	mean /= float64(len(values))
+	// Two passes, which avoids the cancellation of subtracting the squared mean
+	for _, x := range values {
+		variance += (x - mean) * (x - mean)
+	}
+	return mean, variance / float64(len(values))
+}
+
+// Returns a sorted copy of observations, or an error if it is empty or has any non-finite value
+func sortedObservations(method string, observations []float64) ([]float64, error) {
+	if len(observations) == 0 {
+		return nil, argumentError(method, "observations", observations, "must not be empty")
+	}
+	for i, x := range observations {
+		if err := checkFinite(method, fmt.Sprintf("observations[%d]", i), x); err != nil {
+			return nil, err
+		}
+	}
+	var sorted = make([]float64, len(observations))
+	copy(sorted, observations)
+	sort.Float64s(sorted)
+	return sorted, nil
+}
@@ -0,0 +1,102 @@
+//go:build ignore
+
+// Generates locked_gen.go, which gives LockedGen a locking wrapper for each method of *Gen.
+// Run with go generate after adding or changing a method of *Gen
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Methods of *Gen that LockedGen doesn't wrap
+var skipped = map[string]bool{
+	// Only exists to point at the real Shuffle()
+	"Shuffle": true,
+}
+
+func main() {
+	var fset = token.NewFileSet()
+	var packages, err = parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		var name = info.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != "locked_gen.go"
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var files []*ast.File
+	for _, file := range packages["randshiro"].Files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return fset.Position(files[i].Pos()).Filename < fset.Position(files[j].Pos()).Filename
+	})
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen_locked.go; DO NOT EDIT.\n\n")
+	out.WriteString("package randshiro\n\n")
+	out.WriteString("import \"math/big\"\n")
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			var fn, ok = decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() || skipped[fn.Name.Name] || !isGenReceiver(fn) {
+				continue
+			}
+			writeWrapper(&out, fset, fn)
+		}
+	}
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile("locked_gen.go", formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func isGenReceiver(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return false
+	}
+	var star, ok = fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Gen"
+}
+
+func writeWrapper(out *bytes.Buffer, fset *token.FileSet, fn *ast.FuncDecl) {
+	out.WriteString("\n")
+	if fn.Doc != nil {
+		// Copied verbatim, so caveats like missing range checks carry over too
+		for _, comment := range fn.Doc.List {
+			out.WriteString(comment.Text + "\n")
+		}
+	}
+	var args []string
+	for _, param := range fn.Type.Params.List {
+		for _, name := range param.Names {
+			args = append(args, name.Name)
+		}
+	}
+	var signature bytes.Buffer
+	printer.Fprint(&signature, fset, &ast.FuncType{Params: fn.Type.Params, Results: fn.Type.Results})
+	fmt.Fprintf(out, "func (locked *LockedGen) %s%s {\n", fn.Name.Name, strings.TrimPrefix(signature.String(), "func"))
+	out.WriteString("\tlocked.mu.Lock()\n\tdefer locked.mu.Unlock()\n")
+	var call = fmt.Sprintf("locked.rng.%s(%s)", fn.Name.Name, strings.Join(args, ", "))
+	if fn.Type.Results == nil {
+		fmt.Fprintf(out, "\t%s\n}\n", call)
+	} else {
+		fmt.Fprintf(out, "\treturn %s\n}\n", call)
+	}
+}
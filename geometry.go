@@ -0,0 +1,362 @@
+package randshiro
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Returns a uniformly distributed point on the unit circle
+func (rng *Gen) UnitCircle() (float64, float64) {
+	// von Neumann's method: a uniform point in the unit disk
+	// determines a uniform angle, without calling any trig functions
+	for {
+		var u = 2*rng.Float64() - 1
+		var v = 2*rng.Float64() - 1
+		var s = u*u + v*v
+		if s < 1 && s != 0 {
+			return (u*u - v*v) / s, 2 * u * v / s
+		}
+	}
+}
+
+// Returns a uniformly distributed point on the surface of the unit sphere in 3D
+func (rng *Gen) UnitSphere3() (float64, float64, float64) {
+	// Marsaglia's method: https://doi.org/10.1214/aoms/1177692644
+	for {
+		var u = 2*rng.Float64() - 1
+		var v = 2*rng.Float64() - 1
+		var s = u*u + v*v
+		if s < 1 {
+			var scale = 2 * math.Sqrt(1-s)
+			return u * scale, v * scale, 1 - 2*s
+		}
+	}
+}
+
+// Returns a uniformly distributed point inside the unit disk
+func (rng *Gen) UnitDisk() (float64, float64) {
+	// Rejection from the enclosing square accepts ~79% of the time
+	for {
+		var x = 2*rng.Float64() - 1
+		var y = 2*rng.Float64() - 1
+		if x*x+y*y < 1 {
+			return x, y
+		}
+	}
+}
+
+// Returns a uniformly distributed point inside the unit ball in 3D
+func (rng *Gen) UnitBall3() (float64, float64, float64) {
+	// Rejection from the enclosing cube accepts ~52% of the time
+	for {
+		var x = 2*rng.Float64() - 1
+		var y = 2*rng.Float64() - 1
+		var z = 2*rng.Float64() - 1
+		if x*x+y*y+z*z < 1 {
+			return x, y, z
+		}
+	}
+}
+
+// Fills dst with a uniformly distributed point on the surface of the
+// unit sphere in len(dst) dimensions and returns it
+//
+// Prefer UnitCircle()/UnitSphere3() in 2D/3D.
+// Makes no range checks on dst; len(dst) must be at least 1
+func (rng *Gen) UnitSphere(dst []float64) []float64 {
+	// A vector of independent normals points in a uniformly random direction
+	for {
+		for i := 0; i+1 < len(dst); i += 2 {
+			dst[i], dst[i+1] = rng.Normal()
+		}
+		if len(dst)%2 == 1 {
+			dst[len(dst)-1], _ = rng.Normal()
+		}
+		var norm float64
+		for _, x := range dst {
+			norm += x * x
+		}
+		if norm != 0 {
+			norm = math.Sqrt(norm)
+			for i := range dst {
+				dst[i] /= norm
+			}
+			return dst
+		}
+	}
+}
+
+// Fills dst with a uniformly distributed point inside the
+// unit ball in len(dst) dimensions and returns it
+//
+// Prefer UnitDisk()/UnitBall3() in 2D/3D.
+// Makes no range checks on dst; len(dst) must be at least 1
+func (rng *Gen) UnitBall(dst []float64) []float64 {
+	rng.UnitSphere(dst)
+	// The volume inside radius r grows as r^n
+	var radius = math.Pow(rng.Float64(), 1/float64(len(dst)))
+	for i := range dst {
+		dst[i] *= radius
+	}
+	return dst
+}
+
+// Returns a uniformly distributed point inside the annulus (ring) centered on the
+// origin between the circles of radius innerRadius and outerRadius
+//
+// Makes no range checks on innerRadius/outerRadius
+func (rng *Gen) Annulus(innerRadius, outerRadius float64) (float64, float64) {
+	// The area inside radius r grows as r^2
+	var inner2 = innerRadius * innerRadius
+	var radius = math.Sqrt(inner2 + rng.Float64()*(outerRadius*outerRadius-inner2))
+	var x, y = rng.UnitCircle()
+	return x * radius, y * radius
+}
+
+// Fills dst with a uniformly distributed point on the probability simplex
+// in len(dst) dimensions (non-negative float64s that sum to 1) and returns it
+//
+// Makes no range checks on dst; len(dst) must be at least 1
+func (rng *Gen) Simplex(dst []float64) []float64 {
+	// Normalized independent exponentials, which is Dirichlet(1, ..., 1)
+	var sum float64
+	for i := range dst {
+		dst[i] = rng.Exponential()
+		sum += dst[i]
+	}
+	if sum == 0 {
+		// Every exponential rounded to zero, a 1 in 2^(53*len(dst)) event
+		return rng.Simplex(dst)
+	}
+	for i := range dst {
+		dst[i] /= sum
+	}
+	return dst
+}
+
+// Returns a uniformly distributed point inside the triangle with vertices a, b, and c
+func (rng *Gen) Triangle(a, b, c [2]float64) (float64, float64) {
+	var u, v = rng.Float64(), rng.Float64()
+	// Points in the far half of the parallelogram spanned by
+	// the two edges are reflected back into the triangle
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	return a[0] + u*(b[0]-a[0]) + v*(c[0]-a[0]),
+		a[1] + u*(b[1]-a[1]) + v*(c[1]-a[1])
+}
+
+// Samples uniformly distributed points inside a simple polygon
+type Polygon struct {
+	triangles [][3][2]float64
+	// Running total of triangle areas, used to pick a triangle
+	cumulativeAreas []float64
+}
+
+// Returns a *Polygon for the simple (non self-intersecting) polygon with the given vertices,
+// which may be convex or concave and listed in either clockwise or counterclockwise order
+//
+// The polygon is triangulated once here by ear clipping
+func NewPolygon(vertices [][2]float64) (*Polygon, error) {
+	const method = "NewPolygon"
+	if len(vertices) < 3 {
+		return nil, argumentError(method, "len(vertices)", len(vertices), "must be at least 3")
+	}
+	for i, vertex := range vertices {
+		for _, x := range vertex {
+			if err := checkFinite(method, fmt.Sprintf("vertices[%d]", i), x); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var signedArea float64
+	for i, vertex := range vertices {
+		var next = vertices[(i+1)%len(vertices)]
+		signedArea += vertex[0]*next[1] - next[0]*vertex[1]
+	}
+	if signedArea == 0 {
+		return nil, argumentError(method, "vertices", vertices, "must enclose a non-zero area")
+	}
+	// Ear clipping expects counterclockwise order
+	var remaining = make([][2]float64, len(vertices))
+	copy(remaining, vertices)
+	if signedArea < 0 {
+		for i, j := 0, len(remaining)-1; i < j; i, j = i+1, j-1 {
+			remaining[i], remaining[j] = remaining[j], remaining[i]
+		}
+	}
+
+	var polygon = &Polygon{}
+	var total float64
+	for len(remaining) > 2 {
+		var ear = findEar(remaining)
+		if ear < 0 {
+			return nil, argumentError(method, "vertices", vertices, "must form a simple polygon")
+		}
+		var n = len(remaining)
+		var triangle = [3][2]float64{remaining[(ear+n-1)%n], remaining[ear], remaining[(ear+1)%n]}
+		if area := triangleArea(triangle); area > 0 {
+			total += area
+			polygon.triangles = append(polygon.triangles, triangle)
+			polygon.cumulativeAreas = append(polygon.cumulativeAreas, total)
+		}
+		remaining = append(remaining[:ear], remaining[ear+1:]...)
+	}
+	return polygon, nil
+}
+
+// Returns a uniformly distributed point inside polygon
+func (polygon *Polygon) Sample(rng *Gen) (float64, float64) {
+	var total = polygon.cumulativeAreas[len(polygon.cumulativeAreas)-1]
+	var i = sort.SearchFloat64s(polygon.cumulativeAreas, rng.Float64()*total)
+	// Float64()*total can round up to total itself
+	if i == len(polygon.triangles) {
+		i--
+	}
+	var triangle = &polygon.triangles[i]
+	return rng.Triangle(triangle[0], triangle[1], triangle[2])
+}
+
+// Returns the index of a vertex of the counterclockwise polygon
+// that can be clipped off as an ear, or -1 if there isn't one
+func findEar(polygon [][2]float64) int {
+	var n = len(polygon)
+	for i := range polygon {
+		var triangle = [3][2]float64{polygon[(i+n-1)%n], polygon[i], polygon[(i+1)%n]}
+		// Reflex vertices can't be ears
+		if cross(triangle[0], triangle[1], triangle[2]) < 0 {
+			continue
+		}
+		var isEar = true
+		for j, point := range polygon {
+			if j == i || j == (i+n-1)%n || j == (i+1)%n {
+				continue
+			}
+			if point != triangle[0] && point != triangle[2] && inTriangle(point, triangle) {
+				isEar = false
+				break
+			}
+		}
+		if isEar {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the z component of (b - a) x (c - b), which is positive
+// when a, b, c turn counterclockwise
+func cross(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-b[1]) - (b[1]-a[1])*(c[0]-b[0])
+}
+
+// Returns whether point is inside or on the boundary of
+// the counterclockwise triangle
+func inTriangle(point [2]float64, triangle [3][2]float64) bool {
+	return cross(triangle[0], triangle[1], point) >= 0 &&
+		cross(triangle[1], triangle[2], point) >= 0 &&
+		cross(triangle[2], triangle[0], point) >= 0
+}
+
+func triangleArea(triangle [3][2]float64) float64 {
+	return math.Abs(cross(triangle[0], triangle[1], triangle[2])) / 2
+}
+
+// Returns a uniformly distributed unit quaternion as (w, x, y, z),
+// which represents a uniformly random rotation in 3D
+//
+// Uses Shoemake's method from Graphics Gems III
+func (rng *Gen) UniformQuaternion() [4]float64 {
+	var u1, u2, u3 = rng.Float64(), rng.Float64(), rng.Float64()
+	var r1, r2 = math.Sqrt(1 - u1), math.Sqrt(u1)
+	var sin2, cos2 = math.Sincos(2 * math.Pi * u2)
+	var sin3, cos3 = math.Sincos(2 * math.Pi * u3)
+	return [4]float64{r2 * cos3, r1 * sin2, r1 * cos2, r2 * sin3}
+}
+
+// Returns a Haar distributed (uniformly random) n by n orthogonal matrix, as rows
+//
+// Computed from the Householder QR decomposition of a matrix of independent normals,
+// with the signs of Q's columns corrected so that R has a positive diagonal;
+// without that correction the result isn't uniformly distributed.
+// Makes no range checks on n
+func (rng *Gen) RandomOrthogonal(n int) [][]float64 {
+	// Row-major, consumed in the pairs Normal() returns
+	var a = make([]float64, n*n)
+	for i := 0; i+1 < len(a); i += 2 {
+		a[i], a[i+1] = rng.Normal()
+	}
+	if len(a)%2 == 1 {
+		a[len(a)-1], _ = rng.Normal()
+	}
+
+	// Householder vectors, each zero above its own row
+	var reflectors = make([][]float64, n)
+	var signs = make([]float64, n)
+	for k := 0; k < n; k++ {
+		var norm float64
+		for i := k; i < n; i++ {
+			norm += a[i*n+k] * a[i*n+k]
+		}
+		norm = math.Sqrt(norm)
+		signs[k] = 1
+		if norm == 0 {
+			continue
+		}
+		// Reflect column k onto -sign(a[k][k])*norm*e_k, the choice that avoids cancellation
+		var alpha = -math.Copysign(norm, a[k*n+k])
+		var v = make([]float64, n)
+		var vNorm float64
+		for i := k; i < n; i++ {
+			v[i] = a[i*n+k]
+		}
+		v[k] -= alpha
+		for i := k; i < n; i++ {
+			vNorm += v[i] * v[i]
+		}
+		vNorm = math.Sqrt(vNorm)
+		for i := k; i < n; i++ {
+			v[i] /= vNorm
+		}
+		reflectHouseholder(a, n, v, k)
+		reflectors[k] = v
+		// alpha is the diagonal entry of R
+		signs[k] = math.Copysign(1, alpha)
+	}
+
+	// Q = H_0 * H_1 * ... * H_(n-1), built up by applying the reflectors to I in reverse
+	var q = make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		q[i*n+i] = 1
+	}
+	for k := n - 1; k >= 0; k-- {
+		if reflectors[k] != nil {
+			reflectHouseholder(q, n, reflectors[k], k)
+		}
+	}
+
+	var rows = make([][]float64, n)
+	for i := range rows {
+		rows[i] = q[i*n : (i+1)*n : (i+1)*n]
+		for j := range rows[i] {
+			rows[i][j] *= signs[j]
+		}
+	}
+	return rows
+}
+
+// Applies the Householder reflection I - 2*v*v^T to the rows of the
+// n by n row-major matrix m, where v is zero before index k
+func reflectHouseholder(m []float64, n int, v []float64, k int) {
+	for j := 0; j < n; j++ {
+		var dot float64
+		for i := k; i < n; i++ {
+			dot += v[i] * m[i*n+j]
+		}
+		dot *= 2
+		for i := k; i < n; i++ {
+			m[i*n+j] -= dot * v[i]
+		}
+	}
+}
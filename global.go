@@ -0,0 +1,204 @@
+package randshiro
+
+import "sync"
+
+// Backs the package-level functions, each of which borrows a *Gen for the duration of one call
+//
+// sync.Pool keeps a cache per P, so goroutines running in parallel almost
+// always get a *Gen of their own instead of contending on a shared one.
+// Generators dropped by the garbage collector are replaced by freshly seeded ones.
+// The functions don't defer returning the *Gen, which would cost about as much as
+// the call itself; one lost to a panic (e.g. from a bad bound) is simply replaced
+var pool = sync.Pool{New: func() any { return New() }}
+
+// Returns a uniformly distributed uint64, from a *Gen shared by the package-level functions
+func Uint64() uint64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Uint64()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a uint64 in the interval [0, bound), from a *Gen shared by the package-level functions
+//
+// Makes no range checks on bound
+func Uint64n(bound uint64) uint64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Uint64n(bound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns a uniformly distributed uint32, from a *Gen shared by the package-level functions
+func Uint32() uint32 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Uint32()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a uint32 in the interval [0, bound), from a *Gen shared by the package-level functions
+//
+// Makes no range checks on bound
+func Uint32n(bound uint32) uint32 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Uint32n(bound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns a non-negative int64, from a *Gen shared by the package-level functions
+func Int63() int64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Int63()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a non-negative int32, from a *Gen shared by the package-level functions
+func Int31() int32 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Int31()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a non-negative int, from a *Gen shared by the package-level functions
+func Int() int {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Int()
+	pool.Put(rng)
+	return x
+}
+
+// Returns an int in the interval [0, bound), from a *Gen shared by the package-level functions
+//
+// Makes no range checks on bound
+func Intn(bound int) int {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Intn(bound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns an int64 in the interval [0, bound), from a *Gen shared by the package-level functions
+//
+// Makes no range checks on bound
+func Int64n(bound int64) int64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Int64n(bound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns an int32 in the interval [0, bound), from a *Gen shared by the package-level functions
+//
+// Makes no range checks on bound
+func Int32n(bound int32) int32 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Int32n(bound)
+	pool.Put(rng)
+	return x
+}
+
+// Equivalent to Int64n(), for compatibility with math/rand
+func Int63n(bound int64) int64 {
+	return Int64n(bound)
+}
+
+// Equivalent to Int32n(), for compatibility with math/rand
+func Int31n(bound int32) int32 {
+	return Int32n(bound)
+}
+
+// Returns an int in the interval [lowerBound, upperBound), from a *Gen shared by the package-level functions
+//
+// Makes no range checks on lowerBound/upperBound
+func IntRange(lowerBound, upperBound int) int {
+	var rng = pool.Get().(*Gen)
+	var x = rng.IntRange(lowerBound, upperBound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns an int in the interval [lowerBound, upperBound], from a *Gen shared by the package-level functions
+//
+// Makes no range checks on lowerBound/upperBound
+func IntBetween(lowerBound, upperBound int) int {
+	var rng = pool.Get().(*Gen)
+	var x = rng.IntBetween(lowerBound, upperBound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns a bool with 50% odds of being true, from a *Gen shared by the package-level functions
+func Bool() bool {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Bool()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a uniformly distributed float64 in the interval [0.0, 1.0),
+// from a *Gen shared by the package-level functions
+func Float64() float64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Float64()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a uniformly distributed float32 in the interval [0.0, 1.0),
+// from a *Gen shared by the package-level functions
+func Float32() float32 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Float32()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a uniformly distributed float64 in the interval [lowerBound, upperBound),
+// from a *Gen shared by the package-level functions
+//
+// Returns lowerBound if lowerBound == upperBound, and NaN if the interval is otherwise
+// empty or unbounded. Makes no other range checks on lowerBound/upperBound
+func Float64Range(lowerBound, upperBound float64) float64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Float64Range(lowerBound, upperBound)
+	pool.Put(rng)
+	return x
+}
+
+// Returns a normally distributed float64 with mean = 0.0 and stddev = 1.0,
+// from a *Gen shared by the package-level functions
+//
+// Named after its math/rand counterpart, since Normal is taken by the Distribution type.
+// Only returns one of the pair generated by Normal(); a local *Gen is twice as efficient
+func NormFloat64() float64 {
+	var rng = pool.Get().(*Gen)
+	var x, _ = rng.Normal()
+	pool.Put(rng)
+	return x
+}
+
+// Returns an exponentially distributed float64 with a rate constant (lambda) of 1,
+// from a *Gen shared by the package-level functions
+//
+// Named after its math/rand counterpart, since Exponential is taken by the Distribution type
+func ExpFloat64() float64 {
+	var rng = pool.Get().(*Gen)
+	var x = rng.Exponential()
+	pool.Put(rng)
+	return x
+}
+
+// Returns a permutation of ints in the interval [0, n),
+// from a *Gen shared by the package-level functions
+//
+// Makes no range checks on n
+func Perm(n int) []int {
+	var rng = pool.Get().(*Gen)
+	var perm = rng.Perm(n)
+	pool.Put(rng)
+	return perm
+}
@@ -0,0 +1,23 @@
+package randshiro
+
+import "sync"
+
+//go:generate go run gen_locked.go
+
+// Wraps a *Gen with a mutex so that a single instance can be shared between goroutines
+//
+// Has the same methods as *Gen (generated into locked_gen.go), each holding the lock
+// for the duration of the call. Every call contends on the same lock, so when many
+// goroutines need random values it is much faster for each to own a *Gen, or to use
+// the package-level functions, which never contend with each other
+type LockedGen struct {
+	mu  sync.Mutex
+	rng *Gen
+}
+
+// Returns a *LockedGen wrapping rng
+//
+// rng must not be used directly afterwards, since that would bypass the lock
+func NewLockedGen(rng *Gen) *LockedGen {
+	return &LockedGen{rng: rng}
+}
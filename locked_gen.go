@@ -0,0 +1,797 @@
+// Code generated by gen_locked.go; DO NOT EDIT.
+
+package randshiro
+
+import "math/big"
+
+// Returns a uniformly random k-combination of ints in the interval [0, n),
+// sorted in increasing order
+//
+// Makes no range checks on n/k
+func (locked *LockedGen) Combination(n, k int) []int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Combination(n, k)
+}
+
+// Returns the ints in the interval [0, n) that were each independently
+// chosen with probability p, sorted in increasing order
+//
+// Runs in time proportional to the size of the returned subset
+// instead of n by skipping geometrically distributed gaps
+func (locked *LockedGen) Subset(n int, p float64) []int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Subset(n, p)
+}
+
+// Returns a uniformly random partition of n: a slice of positive ints
+// in non-increasing order that sum to n
+//
+// Returns an empty slice if n <= 0
+func (locked *LockedGen) Partition(n int) []int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Partition(n)
+}
+
+// Returns a uniformly random composition of n: a slice of positive ints
+// that sum to n where, unlike Partition(), the order of the parts matters
+//
+// Returns an empty slice if n <= 0
+func (locked *LockedGen) Composition(n int) []int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Composition(n)
+}
+
+// Returns a log-normally distributed float64: the exponential of a normally
+// distributed float64 with mean mu and stddev sigma
+//
+// Makes no range checks on mu/sigma
+func (locked *LockedGen) Lognormal(mu, sigma float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Lognormal(mu, sigma)
+}
+
+// Returns a Weibull distributed float64 with shape k and scale lambda
+//
+// Makes no range checks on k/lambda
+func (locked *LockedGen) Weibull(k, lambda float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Weibull(k, lambda)
+}
+
+// Returns a Pareto distributed float64 with scale (minimum value) xm
+// and shape (tail index) alpha
+//
+// Makes no range checks on xm/alpha
+func (locked *LockedGen) Pareto(xm, alpha float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Pareto(xm, alpha)
+}
+
+// Returns a Cauchy distributed float64 with location x0 and scale gamma
+//
+// Makes no range checks on x0/gamma
+func (locked *LockedGen) Cauchy(x0, gamma float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Cauchy(x0, gamma)
+}
+
+// Returns a Laplace (double exponential) distributed float64
+// with location mu and scale b
+//
+// Makes no range checks on mu/b
+func (locked *LockedGen) Laplace(mu, b float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Laplace(mu, b)
+}
+
+// Returns a logistically distributed float64 with location mu and scale s
+//
+// Makes no range checks on mu/s
+func (locked *LockedGen) Logistic(mu, s float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Logistic(mu, s)
+}
+
+// Returns a Gumbel (type I extreme value) distributed float64
+// with location mu and scale beta
+//
+// Makes no range checks on mu/beta
+func (locked *LockedGen) Gumbel(mu, beta float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Gumbel(mu, beta)
+}
+
+// Returns a gamma distributed float64 with shape k and scale theta
+//
+// Makes no range checks on k/theta
+func (locked *LockedGen) Gamma(k, theta float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Gamma(k, theta)
+}
+
+// Returns a chi-squared distributed float64 with k degrees of freedom
+//
+// Makes no range checks on k
+func (locked *LockedGen) ChiSquared(k float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.ChiSquared(k)
+}
+
+// Returns a Student's t distributed float64 with nu degrees of freedom
+//
+// Makes no range checks on nu
+func (locked *LockedGen) StudentT(nu float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.StudentT(nu)
+}
+
+// Returns an F distributed float64 with d1 and d2 degrees of freedom
+//
+// Makes no range checks on d1/d2
+func (locked *LockedGen) FDist(d1, d2 float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.FDist(d1, d2)
+}
+
+// Returns a noncentral chi-squared distributed float64 with k degrees
+// of freedom and noncentrality parameter lambda
+//
+// Makes no range checks on k/lambda
+func (locked *LockedGen) NoncentralChiSquared(k, lambda float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.NoncentralChiSquared(k, lambda)
+}
+
+// Returns a Poisson distributed int with the given mean
+//
+// Makes no range checks on mean
+func (locked *LockedGen) Poisson(mean float64) int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Poisson(mean)
+}
+
+// Returns a normally distributed float64 with user-defined mean and stddev,
+// truncated to the interval [lowerBound, upperBound]
+//
+// Either bound may be infinite. Unlike rerolling NormalDist() until the result
+// lands in range, this stays fast when the interval is far out in a tail.
+// Makes no range checks on mean/stddev/lowerBound/upperBound
+func (locked *LockedGen) TruncatedNormal(mean, stddev, lowerBound, upperBound float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.TruncatedNormal(mean, stddev, lowerBound, upperBound)
+}
+
+// Returns an exponentially distributed float64 with rate constant lambda,
+// truncated to the interval [0.0, upperBound)
+//
+// upperBound may be infinite. Uses exact inversion of the truncated CDF.
+// Makes no range checks on lambda/upperBound
+func (locked *LockedGen) TruncatedExponential(lambda, upperBound float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.TruncatedExponential(lambda, upperBound)
+}
+
+// Returns a Yule–Simon distributed uint64 in the interval [1, 2^64) with shape rho,
+// a discrete power law whose tail falls off as k^-(rho+1)
+//
+// Makes no range checks on rho
+func (locked *LockedGen) YuleSimon(rho float64) uint64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.YuleSimon(rho)
+}
+
+// Returns a beta distributed float64 in the interval [0.0, 1.0]
+// with shape parameters a and b
+//
+// Makes no range checks on a/b
+func (locked *LockedGen) Beta(a, b float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Beta(a, b)
+}
+
+// Returns a binomially distributed int: the number of successes
+// in n independent trials that each succeed with probability p
+//
+// Runs in time proportional to log(n) rather than n.
+// Makes no range checks on n/p
+func (locked *LockedGen) Binomial(n int, p float64) int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Binomial(n, p)
+}
+
+// Returns a Dirichlet distributed vector with concentration parameters alpha:
+// len(alpha) non-negative float64s that sum to 1
+//
+// Makes no range checks on alpha
+func (locked *LockedGen) Dirichlet(alpha []float64) []float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Dirichlet(alpha)
+}
+
+// Returns multinomially distributed counts: how many of n independent trials
+// landed in each category, where category i is chosen with probability p[i]
+//
+// p doesn't need to be normalized. Runs in time proportional to
+// len(p)*log(n) by drawing each count from a binomial conditioned on the
+// counts before it, rather than simulating each trial.
+// Makes no range checks on n/p
+func (locked *LockedGen) Multinomial(n int, p []float64) []int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Multinomial(n, p)
+}
+
+// Returns a uniformly distributed point on the unit circle
+func (locked *LockedGen) UnitCircle() (float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UnitCircle()
+}
+
+// Returns a uniformly distributed point on the surface of the unit sphere in 3D
+func (locked *LockedGen) UnitSphere3() (float64, float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UnitSphere3()
+}
+
+// Returns a uniformly distributed point inside the unit disk
+func (locked *LockedGen) UnitDisk() (float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UnitDisk()
+}
+
+// Returns a uniformly distributed point inside the unit ball in 3D
+func (locked *LockedGen) UnitBall3() (float64, float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UnitBall3()
+}
+
+// Fills dst with a uniformly distributed point on the surface of the
+// unit sphere in len(dst) dimensions and returns it
+//
+// Prefer UnitCircle()/UnitSphere3() in 2D/3D.
+// Makes no range checks on dst; len(dst) must be at least 1
+func (locked *LockedGen) UnitSphere(dst []float64) []float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UnitSphere(dst)
+}
+
+// Fills dst with a uniformly distributed point inside the
+// unit ball in len(dst) dimensions and returns it
+//
+// Prefer UnitDisk()/UnitBall3() in 2D/3D.
+// Makes no range checks on dst; len(dst) must be at least 1
+func (locked *LockedGen) UnitBall(dst []float64) []float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UnitBall(dst)
+}
+
+// Returns a uniformly distributed point inside the annulus (ring) centered on the
+// origin between the circles of radius innerRadius and outerRadius
+//
+// Makes no range checks on innerRadius/outerRadius
+func (locked *LockedGen) Annulus(innerRadius, outerRadius float64) (float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Annulus(innerRadius, outerRadius)
+}
+
+// Fills dst with a uniformly distributed point on the probability simplex
+// in len(dst) dimensions (non-negative float64s that sum to 1) and returns it
+//
+// Makes no range checks on dst; len(dst) must be at least 1
+func (locked *LockedGen) Simplex(dst []float64) []float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Simplex(dst)
+}
+
+// Returns a uniformly distributed point inside the triangle with vertices a, b, and c
+func (locked *LockedGen) Triangle(a, b, c [2]float64) (float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Triangle(a, b, c)
+}
+
+// Returns a uniformly distributed unit quaternion as (w, x, y, z),
+// which represents a uniformly random rotation in 3D
+//
+// Uses Shoemake's method from Graphics Gems III
+func (locked *LockedGen) UniformQuaternion() [4]float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.UniformQuaternion()
+}
+
+// Returns a Haar distributed (uniformly random) n by n orthogonal matrix, as rows
+//
+// Computed from the Householder QR decomposition of a matrix of independent normals,
+// with the signs of Q's columns corrected so that R has a positive diagonal;
+// without that correction the result isn't uniformly distributed.
+// Makes no range checks on n
+func (locked *LockedGen) RandomOrthogonal(n int) [][]float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.RandomOrthogonal(n)
+}
+
+// Returns a uint64 in the interval [0, 2^64)
+func (locked *LockedGen) Uint64() uint64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint64()
+}
+
+// Returns a uint64 in the interval [0, 2^bitcount)
+//
+// Makes no range checks on bitcount
+func (locked *LockedGen) Uint64bits(bitcount uint) uint64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint64bits(bitcount)
+}
+
+// Returns a uint64 in the interval [0, bound)
+//
+// If bound happens to be a power of two, prefer using Uint64bits()
+func (locked *LockedGen) Uint64n(bound uint64) uint64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint64n(bound)
+}
+
+// Returns a uint32 in the interval [0, 2^32)
+func (locked *LockedGen) Uint32() uint32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint32()
+}
+
+// Returns a uint32 in the interval [0, bound)
+//
+// Cheaper than Uint64n() since the rejection threshold is only computed
+// with 32 bit division on the rare occasions that it's needed
+func (locked *LockedGen) Uint32n(bound uint32) uint32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint32n(bound)
+}
+
+// Returns two independent uint32s in the interval [0, bound)
+//
+// Both values are usually taken from a single call to the backing generator,
+// with only a rejected half being redrawn
+func (locked *LockedGen) FastUint32n(bound uint32) (uint32, uint32) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.FastUint32n(bound)
+}
+
+// Returns a non-negative int64 in the interval [0, 2^63)
+func (locked *LockedGen) Int63() int64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int63()
+}
+
+// Returns a non-negative int32 in the interval [0, 2^31)
+func (locked *LockedGen) Int31() int32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int31()
+}
+
+// Returns a non-negative int
+func (locked *LockedGen) Int() int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int()
+}
+
+// Returns an int64 in the interval [0, bound)
+//
+// Makes no range checks on bound
+func (locked *LockedGen) Int64n(bound int64) int64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int64n(bound)
+}
+
+// Returns an int32 in the interval [0, bound)
+//
+// Makes no range checks on bound
+func (locked *LockedGen) Int32n(bound int32) int32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int32n(bound)
+}
+
+// Returns an int64 in the interval [0, bound)
+//
+// Equivalent to calling Int64n(), named for parity with math/rand
+func (locked *LockedGen) Int63n(bound int64) int64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int63n(bound)
+}
+
+// Returns an int32 in the interval [0, bound)
+//
+// Equivalent to calling Int32n(), named for parity with math/rand
+func (locked *LockedGen) Int31n(bound int32) int32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int31n(bound)
+}
+
+// Returns an int in the interval [0, bound)
+//
+// Makes no range checks on bound
+func (locked *LockedGen) Intn(bound int) int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Intn(bound)
+}
+
+// Returns an int in the interval [lowerBound, upperBound)
+//
+// Works across the full range of int, e.g. IntRange(math.MinInt, math.MaxInt).
+// Makes no other range checks on lowerBound/upperBound
+func (locked *LockedGen) IntRange(lowerBound, upperBound int) int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.IntRange(lowerBound, upperBound)
+}
+
+// Returns an int64 in the interval [lowerBound, upperBound)
+//
+// Works across the full range of int64, e.g. Int64Range(math.MinInt64, math.MaxInt64).
+// Makes no other range checks on lowerBound/upperBound
+func (locked *LockedGen) Int64Range(lowerBound, upperBound int64) int64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int64Range(lowerBound, upperBound)
+}
+
+// Returns a uint64 in the interval [lowerBound, upperBound)
+//
+// Makes no range checks on lowerBound/upperBound
+func (locked *LockedGen) Uint64Range(lowerBound, upperBound uint64) uint64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint64Range(lowerBound, upperBound)
+}
+
+// Returns an int in the interval [lowerBound, upperBound]
+//
+// Unlike IntRange(), upperBound itself can be returned, so
+// IntBetween(math.MinInt, math.MaxInt) covers every int.
+// Makes no other range checks on lowerBound/upperBound
+func (locked *LockedGen) IntBetween(lowerBound, upperBound int) int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.IntBetween(lowerBound, upperBound)
+}
+
+// Returns an int64 in the interval [lowerBound, upperBound]
+//
+// Unlike Int64Range(), upperBound itself can be returned, so
+// Int64Between(math.MinInt64, math.MaxInt64) covers every int64.
+// Makes no other range checks on lowerBound/upperBound
+func (locked *LockedGen) Int64Between(lowerBound, upperBound int64) int64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Int64Between(lowerBound, upperBound)
+}
+
+// Returns a uint64 in the interval [lowerBound, upperBound]
+//
+// Makes no range checks on lowerBound/upperBound
+func (locked *LockedGen) Uint64Between(lowerBound, upperBound uint64) uint64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint64Between(lowerBound, upperBound)
+}
+
+// Returns a bool with n in m odds of being true
+func (locked *LockedGen) Odds(n, m uint64) bool {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Odds(n, m)
+}
+
+// Returns a bool with 50% odds of being true
+func (locked *LockedGen) Bool() bool {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Bool()
+}
+
+// Returns a uniformly distributed float64 in the interval [0.0, 1.0)
+//
+// Don't cast the float64s produced by this function to float32:
+// use Float32() or FastFloat32()
+func (locked *LockedGen) Float64() float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float64()
+}
+
+// Returns a uniformly distributed float32 in the interval [0.0, 1.0)
+//
+// Don't cast the float32s produced by this function to float64:
+// use Float64()
+func (locked *LockedGen) Float32() float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float32()
+}
+
+// Returns a uniformly distributed float64 in the interval (0.0, 1.0)
+func (locked *LockedGen) Float64Open() float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float64Open()
+}
+
+// Returns a uniformly distributed float64 in the interval (0.0, 1.0]
+func (locked *LockedGen) Float64OpenClosed() float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float64OpenClosed()
+}
+
+// Returns a uniformly distributed float64 in the interval [0.0, 1.0]
+func (locked *LockedGen) Float64Closed() float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float64Closed()
+}
+
+// Returns a uniformly distributed float32 in the interval (0.0, 1.0)
+func (locked *LockedGen) Float32Open() float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float32Open()
+}
+
+// Returns a uniformly distributed float32 in the interval (0.0, 1.0]
+func (locked *LockedGen) Float32OpenClosed() float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float32OpenClosed()
+}
+
+// Returns a uniformly distributed float32 in the interval [0.0, 1.0]
+func (locked *LockedGen) Float32Closed() float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float32Closed()
+}
+
+// Returns a uniformly distributed float64 in the interval [lowerBound, upperBound)
+//
+// upperBound is never returned, even when rounding would otherwise produce it.
+// Returns lowerBound if lowerBound == upperBound, and NaN if the interval is otherwise
+// empty or unbounded (lowerBound > upperBound, or either bound is NaN or infinite).
+// Makes no other range checks on lowerBound/upperBound
+func (locked *LockedGen) Float64Range(lowerBound, upperBound float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float64Range(lowerBound, upperBound)
+}
+
+// Returns a uniformly distributed float32 in the interval [lowerBound, upperBound)
+//
+// upperBound is never returned, even when rounding would otherwise produce it.
+// Returns lowerBound if lowerBound == upperBound, and NaN if the interval is otherwise
+// empty or unbounded (lowerBound > upperBound, or either bound is NaN or infinite).
+// Makes no other range checks on lowerBound/upperBound
+func (locked *LockedGen) Float32Range(lowerBound, upperBound float32) float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Float32Range(lowerBound, upperBound)
+}
+
+// Returns two independent and uniformly distributed float32s in the interval [0.0, 1.0)
+//
+// Don't cast the float32s produced by this function to float64:
+// use Float64()
+func (locked *LockedGen) FastFloat32() (float32, float32) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.FastFloat32()
+}
+
+// Returns a float64 in the interval [0.0, 1.0) where every representable
+// float64 in that interval can be returned, with probability equal to its distance
+// to the next representable float64
+//
+// Float64() only returns multiples of 2^-53, which is plenty for almost every use case.
+// This method exists for when the values very close to zero matter,
+// e.g. when estimating tail probabilities
+func (locked *LockedGen) FullFloat64() float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.FullFloat64()
+}
+
+// Returns a float32 in the interval [0.0, 1.0) where every representable
+// float32 in that interval can be returned, with probability equal to its distance
+// to the next representable float32
+//
+// Float32() only returns multiples of 2^-24, which is plenty for almost every use case.
+// This method exists for when the values very close to zero matter,
+// e.g. when estimating tail probabilities
+func (locked *LockedGen) FullFloat32() float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.FullFloat32()
+}
+
+// Returns two independent and normally distributed float64s
+// with mean = 0.0 and stddev = 1.0
+//
+// Use NormalDist() if you need to adjust mean/stddev
+func (locked *LockedGen) Normal() (float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Normal()
+}
+
+// Returns two independent and normally distributed float64s
+// with user-defined mean and stddev
+//
+// Makes no range checks on mean/stddev
+func (locked *LockedGen) NormalDist(mean, stddev float64) (float64, float64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.NormalDist(mean, stddev)
+}
+
+// Returns two independent and normally distributed float32s
+// with mean = 0.0 and stddev = 1.0
+//
+// Like FastFloat32(), both coordinates of each attempt come
+// from a single call to the backing generator
+func (locked *LockedGen) NormalFloat32() (float32, float32) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.NormalFloat32()
+}
+
+// Returns two independent and normally distributed float32s
+// with user-defined mean and stddev
+//
+// Makes no range checks on mean/stddev
+func (locked *LockedGen) NormalDistFloat32(mean, stddev float32) (float32, float32) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.NormalDistFloat32(mean, stddev)
+}
+
+// Returns a von Mises distributed angle in the interval [-pi, pi)
+// with mean direction mu and concentration kappa
+//
+// The von Mises distribution is the circular analogue of the normal distribution,
+// with 1/kappa playing the role of the variance. Uses Best and Fisher's method
+// (https://doi.org/10.2307/2346732), falling back to a uniform angle when kappa is
+// negligible and to the normal approximation when kappa is too large for it to be accurate.
+// Makes no range checks on mu/kappa
+func (locked *LockedGen) VonMises(mu, kappa float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.VonMises(mu, kappa)
+}
+
+// Returns a wrapped Cauchy distributed angle in the interval [-pi, pi)
+// with mean direction mu and scale gamma
+//
+// Makes no range checks on mu/gamma
+func (locked *LockedGen) WrappedCauchy(mu, gamma float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.WrappedCauchy(mu, gamma)
+}
+
+// Returns a wrapped normal distributed angle in the interval [-pi, pi)
+// with mean direction mu and stddev sigma (before wrapping)
+//
+// Makes no range checks on mu/sigma
+func (locked *LockedGen) WrappedNormal(mu, sigma float64) float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.WrappedNormal(mu, sigma)
+}
+
+// Returns an exponentially distributed float64 with
+// a rate constant (lambda) of 1
+//
+// Lambda can be adjusted with: Exponential() / lambda
+func (locked *LockedGen) Exponential() float64 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Exponential()
+}
+
+// Returns an exponentially distributed float32 with
+// a rate constant (lambda) of 1
+//
+// Lambda can be adjusted with: ExponentialFloat32() / lambda
+func (locked *LockedGen) ExponentialFloat32() float32 {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.ExponentialFloat32()
+}
+
+// Returns a permutation of ints in the interval [0, n)
+//
+// Makes no range checks on n
+func (locked *LockedGen) Perm(n int) []int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Perm(n)
+}
+
+// Manually seeds the backing generator of the calling Gen instance
+//
+// Unless you are absolutely certain that you need to use this, you don't
+func (locked *LockedGen) ManualSeed(seed uint64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	locked.rng.ManualSeed(seed)
+}
+
+// Returns a uniformly distributed *big.Int in the interval [0, bound)
+//
+// Panics if bound <= 0
+func (locked *LockedGen) BigIntn(bound *big.Int) *big.Int {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.BigIntn(bound)
+}
+
+// Returns the high and low halves of a uint128 in the interval [0, 2^128)
+func (locked *LockedGen) Uint128() (hi, lo uint64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint128()
+}
+
+// Returns the high and low halves of a uint128 in the interval [0, bound),
+// where bound is given by its high and low halves
+//
+// Makes no range checks on bound; a bound of zero returns zero
+func (locked *LockedGen) Uint128n(boundHi, boundLo uint64) (hi, lo uint64) {
+	locked.mu.Lock()
+	defer locked.mu.Unlock()
+	return locked.rng.Uint128n(boundHi, boundLo)
+}
@@ -0,0 +1,172 @@
+package randshiro
+
+import (
+	"fmt"
+	"math"
+)
+
+// Samples vectors from a multivariate normal distribution
+type MultivariateNormal struct {
+	mean []float64
+	// Lower triangular factor of the covariance matrix, row-major
+	factor []float64
+}
+
+// Returns a *MultivariateNormal with the given mean vector and covariance matrix
+//
+// The covariance matrix must be symmetric and positive semi-definite. It is
+// factored with a Cholesky decomposition, falling back to an LDL decomposition
+// when it is only semi-definite (e.g. when some components are perfectly correlated)
+func NewMultivariateNormal(mean []float64, covariance [][]float64) (*MultivariateNormal, error) {
+	const method = "NewMultivariateNormal"
+	var n = len(mean)
+	if n == 0 {
+		return nil, argumentError(method, "mean", mean, "must not be empty")
+	}
+	for i, x := range mean {
+		if err := checkFinite(method, fmt.Sprintf("mean[%d]", i), x); err != nil {
+			return nil, err
+		}
+	}
+	if len(covariance) != n {
+		return nil, argumentError(method, "len(covariance)", len(covariance), fmt.Sprintf("must equal len(mean) (%d)", n))
+	}
+	var matrix = make([]float64, n*n)
+	for i, row := range covariance {
+		if len(row) != n {
+			return nil, argumentError(method, fmt.Sprintf("len(covariance[%d])", i), len(row), fmt.Sprintf("must equal len(mean) (%d)", n))
+		}
+		for j, x := range row {
+			if err := checkFinite(method, fmt.Sprintf("covariance[%d][%d]", i, j), x); err != nil {
+				return nil, err
+			}
+			matrix[i*n+j] = x
+		}
+	}
+	// Tolerance for round-off, relative to the largest variance
+	var scale float64
+	for i := 0; i < n; i++ {
+		scale = math.Max(scale, math.Abs(matrix[i*n+i]))
+	}
+	var tolerance = 1e-12 * math.Max(scale, math.SmallestNonzeroFloat64) * float64(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(matrix[i*n+j]-matrix[j*n+i]) > tolerance {
+				return nil, argumentError(method, fmt.Sprintf("covariance[%d][%d]", i, j), matrix[i*n+j], fmt.Sprintf("must equal covariance[%d][%d] (%v)", j, i, matrix[j*n+i]))
+			}
+		}
+	}
+
+	var factor, ok = cholesky(matrix, n)
+	if !ok {
+		var err error
+		if factor, err = ldlFactor(matrix, n, scale, tolerance); err != nil {
+			return nil, err
+		}
+	}
+	var dist = &MultivariateNormal{mean: make([]float64, n), factor: factor}
+	copy(dist.mean, mean)
+	return dist, nil
+}
+
+// Returns the number of components in each sampled vector
+func (dist *MultivariateNormal) Dimension() int {
+	return len(dist.mean)
+}
+
+// Fills dst with a vector sampled from dist and returns it
+//
+// Makes no range checks on dst; len(dst) must equal Dimension()
+func (dist *MultivariateNormal) Sample(rng *Gen, dst []float64) []float64 {
+	var n = len(dist.mean)
+	dst = dst[:n]
+	// Independent standard normals, consumed in the pairs Normal() returns
+	for i := 0; i+1 < n; i += 2 {
+		dst[i], dst[i+1] = rng.Normal()
+	}
+	if n%2 == 1 {
+		dst[n-1], _ = rng.Normal()
+	}
+	// mean + factor*z, computed in place from the last row up: row i only
+	// reads entries 0 through i, none of which have been overwritten yet
+	for i := n - 1; i >= 0; i-- {
+		var sum = dist.mean[i]
+		for j, x := range dist.factor[i*n : i*n+i+1] {
+			sum += x * dst[j]
+		}
+		dst[i] = sum
+	}
+	return dst
+}
+
+// Returns the lower triangular L where matrix = L * L^T,
+// or false if matrix isn't positive definite
+func cholesky(matrix []float64, n int) ([]float64, bool) {
+	var factor = make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			var sum = matrix[i*n+j]
+			for k := 0; k < j; k++ {
+				sum -= factor[i*n+k] * factor[j*n+k]
+			}
+			if i == j {
+				if !(sum > 0) {
+					return nil, false
+				}
+				factor[i*n+i] = math.Sqrt(sum)
+			} else {
+				factor[i*n+j] = sum / factor[j*n+j]
+			}
+		}
+	}
+	return factor, true
+}
+
+// Returns the lower triangular L * sqrt(D) where matrix = L * D * L^T,
+// treating pivots within tolerance of zero as exactly zero
+//
+// scale is the largest variance in matrix.
+// Returns an error if matrix isn't positive semi-definite
+func ldlFactor(matrix []float64, n int, scale, tolerance float64) ([]float64, error) {
+	// Entries of a positive semi-definite matrix are bounded by the geometric
+	// mean of the variances they connect, so a zero pivot allows at most this
+	var zeroPivotLimit = math.Sqrt(tolerance * scale)
+	var lower = make([]float64, n*n)
+	var diagonal = make([]float64, n)
+	for j := 0; j < n; j++ {
+		var d = matrix[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= lower[j*n+k] * lower[j*n+k] * diagonal[k]
+		}
+		if d < -tolerance {
+			return nil, argumentError("NewMultivariateNormal", "covariance", fmt.Sprintf("pivot %d = %v", j, d), "must be positive semi-definite")
+		}
+		lower[j*n+j] = 1
+		for i := j + 1; i < n; i++ {
+			var sum = matrix[i*n+j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i*n+k] * lower[j*n+k] * diagonal[k]
+			}
+			if d <= tolerance {
+				// A zero pivot in a positive semi-definite matrix
+				// forces the rest of its column to zero as well
+				if math.Abs(sum) > zeroPivotLimit {
+					return nil, argumentError("NewMultivariateNormal", "covariance", fmt.Sprintf("pivot %d = %v", j, d), "must be positive semi-definite")
+				}
+				continue
+			}
+			lower[i*n+j] = sum / d
+		}
+		if d <= tolerance {
+			d = 0
+		}
+		diagonal[j] = d
+	}
+	for j := 0; j < n; j++ {
+		var root = math.Sqrt(diagonal[j])
+		for i := j; i < n; i++ {
+			lower[i*n+j] *= root
+		}
+	}
+	return lower, nil
+}
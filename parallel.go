@@ -0,0 +1,99 @@
+package randshiro
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Returned by RunParallel() when a task fails
+type TaskError struct {
+	// Index of the failed task
+	Index int
+	// The error returned by the task
+	Err error
+}
+
+// Returns a message naming the failed task and its error
+func (err *TaskError) Error() string {
+	return fmt.Sprintf("randshiro: task %d: %v", err.Index, err.Err)
+}
+
+// Allows errors.Is and errors.As to match the error returned by the task
+func (err *TaskError) Unwrap() error {
+	return err.Err
+}
+
+// Returns the seed RunParallel() gives task i, so that a single task
+// can be rerun on its own (e.g. while debugging) with ManualSeed()
+//
+// Each seed depends on both rootSeed and task. They are mixed before being combined,
+// since seeds like rootSeed+task would be expanded by ManualSeed() into overlapping states
+func TaskSeed(rootSeed uint64, task int) uint64 {
+	return mix64(rootSeed ^ mix64(uint64(task)+0x9e3779b97f4a7c15))
+}
+
+// Calls fn once for each task in the interval [0, tasks), spread across GOMAXPROCS goroutines
+//
+// The rng passed to fn is seeded with TaskSeed(rootSeed, i) right before task i runs,
+// so the values each task draws depend only on rootSeed and i, never on scheduling
+// or on how many goroutines are used. It is reused for later tasks, so fn must not
+// keep it (or pass it to other goroutines) after returning.
+//
+// Tasks are started in increasing order. Once a task fails or ctx is done no more are started,
+// but those already running are allowed to finish. If any task failed, the error from the one with
+// the lowest index is returned wrapped in a *TaskError; since every task before it had already
+// started, this is the same error a sequential loop would return. Otherwise, if ctx ended before
+// every task ran, ctx.Err() is returned. Returns an *ArgumentError if tasks is negative
+func RunParallel(ctx context.Context, rootSeed uint64, tasks int, fn func(i int, rng *Gen) error) error {
+	if tasks < 0 {
+		return argumentError("RunParallel", "tasks", tasks, "must not be negative")
+	}
+	var workers = runtime.GOMAXPROCS(0)
+	if workers > tasks {
+		workers = tasks
+	}
+	var workerCtx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	var next, completed int64
+	var mu sync.Mutex
+	var firstErr *TaskError
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			// Seeding overwrites the entire state, so no crypto/rand seed is needed
+			var rng = &Gen{new(x256pp)}
+			for workerCtx.Err() == nil {
+				var i = int(atomic.AddInt64(&next, 1) - 1)
+				if i >= tasks {
+					return
+				}
+				rng.ManualSeed(TaskSeed(rootSeed, i))
+				if err := fn(i, rng); err != nil {
+					mu.Lock()
+					if firstErr == nil || i < firstErr.Index {
+						firstErr = &TaskError{Index: i, Err: err}
+					}
+					mu.Unlock()
+					cancel()
+					continue
+				}
+				atomic.AddInt64(&completed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if completed < int64(tasks) {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,436 @@
+package randshiro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Returned by ParseFloat64() and ParseString() when a spec can't be parsed
+type ParseError struct {
+	// The spec that was being parsed
+	Spec string
+	// Byte offset of Token in Spec
+	Offset int
+	// The offending token, or "" if the spec ended too early
+	Token string
+	// Why the token was rejected
+	Reason string
+	// The *ArgumentError behind the rejection when the spec is well-formed
+	// but a parameter is out of range, otherwise nil
+	Err error
+}
+
+// Returns the spec, where in it parsing failed, and why
+func (err *ParseError) Error() string {
+	var where = fmt.Sprintf("at offset %d (%q)", err.Offset, err.Token)
+	if err.Token == "" {
+		where = "at end of input"
+	}
+	return fmt.Sprintf("randshiro: parsing %q: %s: %s", err.Spec, where, err.Reason)
+}
+
+// Allows errors.Is(err, ErrInvalidArgument) to match parameters that are out of range
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+// A distribution bound to the *Gen it draws from, as returned by ParseFloat64() and ParseString()
+//
+// Like the *Gen it is bound to, instances are not threadsafe
+type Sampler[T any] struct {
+	rng  *Gen
+	dist Distribution[T]
+	spec string
+}
+
+// Returns a sample from the parsed distribution
+func (sampler *Sampler[T]) Sample() T {
+	return sampler.dist.Sample(sampler.rng)
+}
+
+// Returns the parsed distribution, which can be sampled with any *Gen
+func (sampler *Sampler[T]) Distribution() Distribution[T] {
+	return sampler.dist
+}
+
+// Returns the spec in canonical form; parsing it again gives an identical distribution
+func (sampler *Sampler[T]) String() string {
+	return sampler.spec
+}
+
+// Parses spec into a float64 distribution bound to rng
+//
+// Recognized specs are
+//
+//	normal(mu, sigma)
+//	exp(rate)
+//	uniform(lo, hi)
+//	choice(x1:weight1, x2:weight2, ...)
+//
+// where uniform() covers the interval [lo, hi) and each x of choice() is a
+// number picked with probability proportional to its weight.
+// Whitespace between tokens is ignored
+func ParseFloat64(rng *Gen, spec string) (*Sampler[float64], error) {
+	var call, err = parseCall(spec)
+	if err != nil {
+		return nil, err
+	}
+	var dist Distribution[float64]
+	var canonical string
+	switch call.name.text {
+	case "normal":
+		var params, err = call.numbers(spec, "mu", "sigma")
+		if err != nil {
+			return nil, err
+		}
+		if err = call.check(spec, 1, checkNonNegative("ParseFloat64", "sigma", params[1])); err != nil {
+			return nil, err
+		}
+		dist = Normal{params[0], params[1]}
+		canonical = fmt.Sprintf("normal(%s, %s)", formatFloat(params[0]), formatFloat(params[1]))
+	case "exp":
+		var params, err = call.numbers(spec, "rate")
+		if err != nil {
+			return nil, err
+		}
+		if err = call.check(spec, 0, checkPositive("ParseFloat64", "rate", params[0])); err != nil {
+			return nil, err
+		}
+		dist = Exponential{params[0]}
+		canonical = fmt.Sprintf("exp(%s)", formatFloat(params[0]))
+	case "uniform":
+		var params, err = call.numbers(spec, "lo", "hi")
+		if err != nil {
+			return nil, err
+		}
+		if !(params[0] < params[1]) {
+			var argErr = argumentError("ParseFloat64", "hi", params[1], fmt.Sprintf("must be greater than lo (%v)", params[0]))
+			return nil, call.check(spec, 1, argErr)
+		}
+		dist = Uniform{params[0], params[1]}
+		canonical = fmt.Sprintf("uniform(%s, %s)", formatFloat(params[0]), formatFloat(params[1]))
+	case "choice":
+		var choices, err = parseChoice(spec, "ParseFloat64", call, func(label token) (float64, error) {
+			var x, err = parseNumber(spec, label)
+			if err != nil {
+				return 0, err
+			}
+			if argErr := checkFinite("ParseFloat64", "label", x); argErr != nil {
+				return 0, label.wrap(spec, argErr)
+			}
+			// Folds -0 into 0, so that the two can't end up as separate choices
+			return x + 0, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		dist = choices
+		canonical = choices.format(formatFloat)
+	default:
+		return nil, call.name.errorAt(spec, "unknown distribution, expected one of normal, exp, uniform, choice")
+	}
+	return &Sampler[float64]{rng: rng, dist: dist, spec: canonical}, nil
+}
+
+// Parses spec into a string distribution bound to rng
+//
+// The only recognized spec is
+//
+//	choice(label1:weight1, label2:weight2, ...)
+//
+// where each label is picked with probability proportional to its weight.
+// Labels containing whitespace or any of ( ) , : " must be double quoted,
+// using the same escapes as Go string literals
+func ParseString(rng *Gen, spec string) (*Sampler[string], error) {
+	var call, err = parseCall(spec)
+	if err != nil {
+		return nil, err
+	}
+	if call.name.text != "choice" {
+		return nil, call.name.errorAt(spec, "unknown distribution, expected choice")
+	}
+	choices, err := parseChoice(spec, "ParseString", call, func(label token) (string, error) {
+		return label.text, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Sampler[string]{rng: rng, dist: choices, spec: choices.format(formatLabel)}, nil
+}
+
+// Picks one of a fixed set of values, weighted
+type choice[T comparable] struct {
+	values  []T
+	weights []float64
+	table   aliasTable
+}
+
+func (dist *choice[T]) Sample(rng *Gen) T {
+	return dist.values[dist.table.sample(rng)]
+}
+
+func (dist *choice[T]) format(formatValue func(T) string) string {
+	var builder strings.Builder
+	builder.WriteString("choice(")
+	for i, value := range dist.values {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(formatValue(value))
+		builder.WriteByte(':')
+		builder.WriteString(formatFloat(dist.weights[i]))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+func parseChoice[T comparable](spec, method string, call call, parseValue func(token) (T, error)) (*choice[T], error) {
+	if len(call.args) == 0 {
+		return nil, call.end.errorAt(spec, "choice needs at least one label:weight pair")
+	}
+	var dist = &choice[T]{}
+	var seen = make(map[T]bool, len(call.args))
+	for _, arg := range call.args {
+		if arg.label == nil {
+			return nil, arg.value.errorAt(spec, "expected a label:weight pair")
+		}
+		var value, err = parseValue(*arg.label)
+		if err != nil {
+			return nil, err
+		}
+		if seen[value] {
+			return nil, arg.label.errorAt(spec, "duplicate label")
+		}
+		seen[value] = true
+		weight, err := parseNumber(spec, arg.value)
+		if err != nil {
+			return nil, err
+		}
+		if argErr := checkNonNegative(method, "weight", weight); argErr != nil {
+			return nil, arg.value.wrap(spec, argErr)
+		}
+		dist.values = append(dist.values, value)
+		dist.weights = append(dist.weights, weight)
+	}
+	if err := checkWeights(method, "weights", dist.weights); err != nil {
+		return nil, call.end.wrap(spec, err)
+	}
+	dist.table = newAliasTable(dist.weights)
+	return dist, nil
+}
+
+// A spec split into its distribution name and arguments
+type call struct {
+	name token
+	args []argument
+	// The closing parenthesis
+	end token
+}
+
+// An argument, optionally preceded by "label:"
+type argument struct {
+	label *token
+	value token
+}
+
+// Parses the arguments of call as plain numbers, one for each of names
+func (call call) numbers(spec string, names ...string) ([]float64, error) {
+	if len(call.args) < len(names) {
+		return nil, call.end.errorAt(spec, fmt.Sprintf("expected %s(%s), got %d arguments",
+			call.name.text, strings.Join(names, ", "), len(call.args)))
+	}
+	if len(call.args) > len(names) {
+		var extra = call.args[len(names)]
+		if extra.label != nil {
+			extra.value = *extra.label
+		}
+		return nil, extra.value.errorAt(spec, fmt.Sprintf("expected %s(%s), got %d arguments",
+			call.name.text, strings.Join(names, ", "), len(call.args)))
+	}
+	var params = make([]float64, len(names))
+	for i, arg := range call.args {
+		if arg.label != nil {
+			return nil, arg.label.errorAt(spec, "only choice takes label:weight pairs")
+		}
+		var x, err = parseNumber(spec, arg.value)
+		if err != nil {
+			return nil, err
+		}
+		if argErr := checkFinite("ParseFloat64", names[i], x); argErr != nil {
+			return nil, arg.value.wrap(spec, argErr)
+		}
+		params[i] = x
+	}
+	return params, nil
+}
+
+// Attributes argErr, if any, to the i-th argument of call
+func (call call) check(spec string, i int, argErr error) error {
+	if argErr == nil {
+		return nil
+	}
+	return call.args[i].value.wrap(spec, argErr)
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	// A run of characters other than whitespace, punctuation, and quotes
+	tokenWord
+	// A double quoted string; text holds the unquoted value
+	tokenQuoted
+	// One of ( ) , :
+	tokenPunct
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+	// The token as it appears in the spec
+	raw string
+}
+
+func (tok token) errorAt(spec, reason string) error {
+	return &ParseError{Spec: spec, Offset: tok.offset, Token: tok.raw, Reason: reason}
+}
+
+// Turns an *ArgumentError from one of the check helpers into a *ParseError pointing at tok
+func (tok token) wrap(spec string, argErr error) error {
+	var reason = argErr.Error()
+	if err, ok := argErr.(*ArgumentError); ok {
+		reason = fmt.Sprintf("%s = %v %s", err.Argument, err.Value, err.Reason)
+	}
+	return &ParseError{Spec: spec, Offset: tok.offset, Token: tok.raw, Reason: reason, Err: argErr}
+}
+
+func isPunct(r rune) bool {
+	return r == '(' || r == ')' || r == ',' || r == ':'
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !isPunct(r) && r != '"'
+}
+
+func tokenize(spec string) ([]token, error) {
+	var tokens []token
+	var i = 0
+	for i < len(spec) {
+		var r, size = utf8.DecodeRuneInString(spec[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case isPunct(r):
+			tokens = append(tokens, token{kind: tokenPunct, text: spec[i : i+size], offset: i, raw: spec[i : i+size]})
+			i += size
+		case r == '"':
+			var end = i + 1
+			for end < len(spec) && spec[end] != '"' {
+				if spec[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(spec) {
+				return nil, &ParseError{Spec: spec, Offset: i, Token: spec[i:], Reason: "unterminated quoted label"}
+			}
+			var raw = spec[i : end+1]
+			var text, err = strconv.Unquote(raw)
+			if err != nil {
+				return nil, &ParseError{Spec: spec, Offset: i, Token: raw, Reason: "invalid quoted label"}
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, text: text, offset: i, raw: raw})
+			i = end + 1
+		default:
+			var end = i
+			for end < len(spec) {
+				var r, size = utf8.DecodeRuneInString(spec[end:])
+				if !isWordRune(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: spec[i:end], offset: i, raw: spec[i:end]})
+			i = end
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, offset: len(spec)}), nil
+}
+
+// Parses the shared structure of every spec: name(arg, label:arg, ...)
+func parseCall(spec string) (call, error) {
+	var tokens, err = tokenize(spec)
+	if err != nil {
+		return call{}, err
+	}
+	var result call
+	result.name = tokens[0]
+	if result.name.kind != tokenWord {
+		return call{}, result.name.errorAt(spec, "expected a distribution name")
+	}
+	if tokens[1].text != "(" || tokens[1].kind != tokenPunct {
+		return call{}, tokens[1].errorAt(spec, `expected "("`)
+	}
+	var i = 2
+	var isValue = func(tok token) bool { return tok.kind == tokenWord || tok.kind == tokenQuoted }
+	if !(tokens[i].kind == tokenPunct && tokens[i].text == ")") {
+		for {
+			if !isValue(tokens[i]) {
+				return call{}, tokens[i].errorAt(spec, "expected an argument")
+			}
+			var arg = argument{value: tokens[i]}
+			i++
+			if tokens[i].kind == tokenPunct && tokens[i].text == ":" {
+				var label = arg.value
+				arg.label = &label
+				i++
+				if !isValue(tokens[i]) {
+					return call{}, tokens[i].errorAt(spec, "expected a weight")
+				}
+				arg.value = tokens[i]
+				i++
+			}
+			result.args = append(result.args, arg)
+			if tokens[i].kind == tokenPunct && tokens[i].text == "," {
+				i++
+				continue
+			}
+			if tokens[i].kind == tokenPunct && tokens[i].text == ")" {
+				break
+			}
+			return call{}, tokens[i].errorAt(spec, `expected "," or ")"`)
+		}
+	}
+	result.end = tokens[i]
+	if tokens[i+1].kind != tokenEnd {
+		return call{}, tokens[i+1].errorAt(spec, `unexpected token after ")"`)
+	}
+	return result, nil
+}
+
+func parseNumber(spec string, tok token) (float64, error) {
+	if tok.kind != tokenWord {
+		return 0, tok.errorAt(spec, "expected a number")
+	}
+	var x, err = strconv.ParseFloat(tok.text, 64)
+	if err != nil {
+		return 0, tok.errorAt(spec, "expected a number")
+	}
+	return x, nil
+}
+
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+// Quotes label only when it wouldn't otherwise be read back as a single word
+func formatLabel(label string) string {
+	if label == "" || strings.IndexFunc(label, func(r rune) bool { return !isWordRune(r) }) >= 0 {
+		return strconv.Quote(label)
+	}
+	return label
+}
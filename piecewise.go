@@ -0,0 +1,164 @@
+package randshiro
+
+import (
+	"fmt"
+	"math"
+)
+
+// A distribution whose density is constant between each pair of
+// neighbouring boundaries, like C++'s std::piecewise_constant_distribution
+type PiecewiseConstant struct {
+	boundaries []float64
+	// Normalized so that the density integrates to 1
+	densities []float64
+	segments  aliasTable
+}
+
+// Returns a *PiecewiseConstant where densities[i] is the density between boundaries[i] and boundaries[i+1]
+//
+// boundaries must be finite and strictly increasing, and there must be exactly one
+// fewer density than boundaries. densities don't need to be normalized, but they must
+// be finite, not negative, and not all zero
+func NewPiecewiseConstant(boundaries, densities []float64) (*PiecewiseConstant, error) {
+	const method = "NewPiecewiseConstant"
+	if err := checkBoundaries(method, boundaries); err != nil {
+		return nil, err
+	}
+	if len(densities) != len(boundaries)-1 {
+		return nil, argumentError(method, "len(densities)", len(densities), fmt.Sprintf("must equal len(boundaries)-1 (%d)", len(boundaries)-1))
+	}
+	var masses = make([]float64, len(densities))
+	for i, density := range densities {
+		if err := checkNonNegative(method, fmt.Sprintf("densities[%d]", i), density); err != nil {
+			return nil, err
+		}
+		masses[i] = density * (boundaries[i+1] - boundaries[i])
+	}
+	var total, err = totalMass(method, densities, masses)
+	if err != nil {
+		return nil, err
+	}
+	var dist = &PiecewiseConstant{
+		boundaries: make([]float64, len(boundaries)),
+		densities:  make([]float64, len(densities)),
+		segments:   newAliasTable(masses),
+	}
+	copy(dist.boundaries, boundaries)
+	for i, density := range densities {
+		dist.densities[i] = density / total
+	}
+	return dist, nil
+}
+
+// Returns a float64 in the interval [boundaries[0], boundaries[len(boundaries)-1])
+func (dist *PiecewiseConstant) Sample(rng *Gen) float64 {
+	var i = dist.segments.sample(rng)
+	return rng.Float64Range(dist.boundaries[i], dist.boundaries[i+1])
+}
+
+// Returns a copy of the boundaries
+func (dist *PiecewiseConstant) Boundaries() []float64 {
+	return append([]float64(nil), dist.boundaries...)
+}
+
+// Returns a copy of the densities, normalized so that they integrate to 1
+func (dist *PiecewiseConstant) Densities() []float64 {
+	return append([]float64(nil), dist.densities...)
+}
+
+// A distribution whose density is linear between each pair of
+// neighbouring boundaries, like C++'s std::piecewise_linear_distribution
+type PiecewiseLinear struct {
+	boundaries []float64
+	// Normalized so that the density integrates to 1
+	densities []float64
+	segments  aliasTable
+}
+
+// Returns a *PiecewiseLinear where densities[i] is the density at boundaries[i]
+//
+// boundaries must be finite and strictly increasing, and there must be exactly one
+// density for each boundary. densities don't need to be normalized, but they must
+// be finite, not negative, and not all zero
+func NewPiecewiseLinear(boundaries, densities []float64) (*PiecewiseLinear, error) {
+	const method = "NewPiecewiseLinear"
+	if err := checkBoundaries(method, boundaries); err != nil {
+		return nil, err
+	}
+	if len(densities) != len(boundaries) {
+		return nil, argumentError(method, "len(densities)", len(densities), fmt.Sprintf("must equal len(boundaries) (%d)", len(boundaries)))
+	}
+	for i, density := range densities {
+		if err := checkNonNegative(method, fmt.Sprintf("densities[%d]", i), density); err != nil {
+			return nil, err
+		}
+	}
+	var masses = make([]float64, len(densities)-1)
+	for i := range masses {
+		masses[i] = (densities[i] + densities[i+1]) / 2 * (boundaries[i+1] - boundaries[i])
+	}
+	var total, err = totalMass(method, densities, masses)
+	if err != nil {
+		return nil, err
+	}
+	var dist = &PiecewiseLinear{
+		boundaries: make([]float64, len(boundaries)),
+		densities:  make([]float64, len(densities)),
+		segments:   newAliasTable(masses),
+	}
+	copy(dist.boundaries, boundaries)
+	for i, density := range densities {
+		dist.densities[i] = density / total
+	}
+	return dist, nil
+}
+
+// Returns a float64 in the interval [boundaries[0], boundaries[len(boundaries)-1]]
+func (dist *PiecewiseLinear) Sample(rng *Gen) float64 {
+	var i = dist.segments.sample(rng)
+	var t = linearInversion(dist.densities[i], dist.densities[i+1], rng.Float64())
+	var lo, hi = dist.boundaries[i], dist.boundaries[i+1]
+	return lo + t*(hi-lo)
+}
+
+// Returns a copy of the boundaries
+func (dist *PiecewiseLinear) Boundaries() []float64 {
+	return append([]float64(nil), dist.boundaries...)
+}
+
+// Returns a copy of the densities, normalized so that they integrate to 1
+func (dist *PiecewiseLinear) Densities() []float64 {
+	return append([]float64(nil), dist.densities...)
+}
+
+// Returns an error unless boundaries has at least two elements, all finite and strictly increasing
+func checkBoundaries(method string, boundaries []float64) error {
+	if len(boundaries) < 2 {
+		return argumentError(method, "len(boundaries)", len(boundaries), "must be at least 2")
+	}
+	for i, x := range boundaries {
+		if err := checkFinite(method, fmt.Sprintf("boundaries[%d]", i), x); err != nil {
+			return err
+		}
+		if i > 0 && !(x > boundaries[i-1]) {
+			return argumentError(method, fmt.Sprintf("boundaries[%d]", i), x, fmt.Sprintf("must be greater than boundaries[%d] (%v)", i-1, boundaries[i-1]))
+		}
+	}
+	return nil
+}
+
+// Returns the sum of the segment masses, or an error unless it is finite and positive
+func totalMass(method string, densities, masses []float64) (float64, error) {
+	var total float64
+	for _, mass := range masses {
+		total += mass
+	}
+	if total == 0 {
+		return 0, argumentError(method, "densities", densities, "must not integrate to 0")
+	}
+	// Also catches segments too wide for a float64, whose masses come out as +Inf or NaN
+	if !(total < math.Inf(1)) {
+		return 0, argumentError(method, "densities", densities, "must integrate to a finite total")
+	}
+	return total, nil
+}
@@ -1,8 +1,16 @@
 package randshiro
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -278,3 +286,1834 @@ func BenchmarkShuffleInts100(b *testing.B) {
 		Shuffle(rng, slice)
 	}
 }
+
+func BenchmarkCombination10of100(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Combination(100, 10)
+	}
+}
+
+func BenchmarkSubset100(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Subset(100, 0.1)
+	}
+}
+
+func BenchmarkPartition100(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Partition(100)
+	}
+}
+
+func BenchmarkComposition100(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Composition(100)
+	}
+}
+
+// Samples n times and checks that each outcome occurs within 5 standard deviations of
+// its expected frequency, and that no outcome outside of want occurs at all
+func checkFrequencies(t *testing.T, name string, n int, sample func() string, want map[string]float64) {
+	t.Helper()
+	var counts = make(map[string]int, len(want))
+	for i := 0; i < n; i++ {
+		counts[sample()]++
+	}
+	for outcome := range counts {
+		if _, ok := want[outcome]; !ok {
+			t.Errorf("%s: returned %s, which should be impossible", name, outcome)
+			return
+		}
+	}
+	for outcome, p := range want {
+		var expected = float64(n) * p
+		if math.Abs(float64(counts[outcome])-expected) > 5*math.Sqrt(expected*(1-p)) {
+			t.Errorf("%s: %s occurred %d times, want about %.0f", name, outcome, counts[outcome], expected)
+		}
+	}
+}
+
+func TestCombinatoricsUniformity(t *testing.T) {
+	var rng = newSeededGen()
+	const n = 60000
+	var sprint = func(slice []int) string { return fmt.Sprint(slice) }
+
+	// All 10 2-combinations of 5
+	var combinations = map[string]float64{}
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			combinations[sprint([]int{i, j})] = 1.0 / 10
+		}
+	}
+	checkFrequencies(t, "Combination(5, 2)", n, func() string { return sprint(rng.Combination(5, 2)) }, combinations)
+
+	// All 32 subsets of 5, each element included with probability 0.3
+	var subsets = map[string]float64{}
+	for mask := 0; mask < 32; mask++ {
+		var subset = []int{}
+		var p = 1.0
+		for i := 0; i < 5; i++ {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, i)
+				p *= 0.3
+			} else {
+				p *= 0.7
+			}
+		}
+		subsets[sprint(subset)] = p
+	}
+	checkFrequencies(t, "Subset(5, 0.3)", n, func() string { return sprint(rng.Subset(5, 0.3)) }, subsets)
+
+	// All 11 partitions of 6
+	var partitions = map[string]float64{}
+	for _, partition := range [][]int{
+		{6}, {5, 1}, {4, 2}, {4, 1, 1}, {3, 3}, {3, 2, 1}, {3, 1, 1, 1},
+		{2, 2, 2}, {2, 2, 1, 1}, {2, 1, 1, 1, 1}, {1, 1, 1, 1, 1, 1},
+	} {
+		partitions[sprint(partition)] = 1.0 / 11
+	}
+	checkFrequencies(t, "Partition(6)", n, func() string { return sprint(rng.Partition(6)) }, partitions)
+
+	// All 8 compositions of 4
+	var compositions = map[string]float64{}
+	for _, composition := range [][]int{
+		{4}, {3, 1}, {1, 3}, {2, 2}, {2, 1, 1}, {1, 2, 1}, {1, 1, 2}, {1, 1, 1, 1},
+	} {
+		compositions[sprint(composition)] = 1.0 / 8
+	}
+	checkFrequencies(t, "Composition(4)", n, func() string { return sprint(rng.Composition(4)) }, compositions)
+
+	for _, c := range []struct {
+		name   string
+		result []int
+	}{
+		{"Subset(0, 0.5)", rng.Subset(0, 0.5)},
+		{"Subset(5, 0)", rng.Subset(5, 0)},
+		{"Partition(0)", rng.Partition(0)},
+		{"Composition(-1)", rng.Composition(-1)},
+	} {
+		if c.result == nil || len(c.result) != 0 {
+			t.Errorf("%s = %v, want an empty slice", c.name, c.result)
+		}
+	}
+	if subset := rng.Subset(4, 1); !reflect.DeepEqual(subset, []int{0, 1, 2, 3}) {
+		t.Errorf("Subset(4, 1) = %v, want every element", subset)
+	}
+	// Large enough that Composition() needs more than one Uint64()
+	var sum = 0
+	for _, part := range rng.Composition(200) {
+		sum += part
+	}
+	if sum != 200 {
+		t.Errorf("Composition(200) sums to %d", sum)
+	}
+}
+
+func TestPermRankRoundTrip(t *testing.T) {
+	const n = 6
+	var count = PermCount(n)
+	for rank := uint64(0); rank < count; rank++ {
+		var perm = PermUnrank(n, rank)
+		if got := PermRank(perm); got != rank {
+			t.Fatalf("PermRank(%v) = %d, want %d", perm, got, rank)
+		}
+		if got := PermUnrankBig(n, new(big.Int).SetUint64(rank)); !reflect.DeepEqual(got, perm) {
+			t.Fatalf("PermUnrankBig(%d, %d) = %v, want %v", n, rank, got, perm)
+		}
+		if rank > 0 && !lexicographicallyLess(PermUnrank(n, rank-1), perm) {
+			t.Fatalf("PermUnrank(%d, %d) is not lexicographically after rank %d", n, rank, rank-1)
+		}
+	}
+	var rng = New()
+	var perm = rng.Perm(30)
+	if got := PermUnrankBig(len(perm), PermRankBig(perm)); !reflect.DeepEqual(got, perm) {
+		t.Fatalf("PermUnrankBig(PermRankBig(%v)) = %v", perm, got)
+	}
+}
+
+func TestCombinationRankRoundTrip(t *testing.T) {
+	const n, k = 9, 4
+	var count = CombinationCount(n, k)
+	if count != CombinationCountBig(n, k).Uint64() {
+		t.Fatalf("CombinationCount(%d, %d) = %d, want %v", n, k, count, CombinationCountBig(n, k))
+	}
+	for rank := uint64(0); rank < count; rank++ {
+		var combination = CombinationUnrank(n, k, rank)
+		if got := CombinationRank(n, combination); got != rank {
+			t.Fatalf("CombinationRank(%v) = %d, want %d", combination, got, rank)
+		}
+		if got := CombinationRankBig(n, combination); got.Uint64() != rank {
+			t.Fatalf("CombinationRankBig(%v) = %v, want %d", combination, got, rank)
+		}
+		if rank > 0 && !lexicographicallyLess(CombinationUnrank(n, k, rank-1), combination) {
+			t.Fatalf("CombinationUnrank(%d, %d, %d) is not lexicographically after rank %d", n, k, rank, rank-1)
+		}
+	}
+	var rng = New()
+	var combination = rng.Combination(200, 50)
+	if got := CombinationUnrankBig(200, 50, CombinationRankBig(200, combination)); !reflect.DeepEqual(got, combination) {
+		t.Fatalf("CombinationUnrankBig(CombinationRankBig(%v)) = %v", combination, got)
+	}
+}
+
+func lexicographicallyLess(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func TestUint128n(t *testing.T) {
+	var rng = New()
+	var two128 = new(big.Int).Lsh(big.NewInt(1), 128)
+	for i := 0; i < 10000; i++ {
+		// negMod128 expects bound >= 2^64
+		var boundHi, boundLo = rng.Uint64bits(uint(i%64+1)) | 1, rng.Uint64()
+		var bound = uint128ToBig(boundHi, boundLo)
+		var want = new(big.Int).Sub(two128, bound)
+		want.Mod(want, bound)
+		if hi, lo := negMod128(boundHi, boundLo); uint128ToBig(hi, lo).Cmp(want) != 0 {
+			t.Fatalf("negMod128(%v) = %v, want %v", bound, uint128ToBig(hi, lo), want)
+		}
+		if hi, lo := rng.Uint128n(boundHi, boundLo); uint128ToBig(hi, lo).Cmp(bound) >= 0 {
+			t.Fatalf("Uint128n(%v) = %v", bound, uint128ToBig(hi, lo))
+		}
+		var aHi, aLo = rng.Uint128()
+		var w3, w2, w1, w0 = mul128(aHi, aLo, boundHi, boundLo)
+		var product = uint128ToBig(w3, w2)
+		product.Lsh(product, 128).Add(product, uint128ToBig(w1, w0))
+		if want := new(big.Int).Mul(uint128ToBig(aHi, aLo), bound); product.Cmp(want) != 0 {
+			t.Fatalf("mul128 = %v, want %v", product, want)
+		}
+	}
+}
+
+func TestBigIntn(t *testing.T) {
+	var rng = New()
+	for _, bound := range []*big.Int{big.NewInt(1), big.NewInt(3), PermCountBig(25), CombinationCountBig(1000, 500)} {
+		for i := 0; i < 1000; i++ {
+			if got := rng.BigIntn(bound); got.Sign() < 0 || got.Cmp(bound) >= 0 {
+				t.Fatalf("BigIntn(%v) = %v", bound, got)
+			}
+		}
+	}
+}
+
+func uint128ToBig(hi, lo uint64) *big.Int {
+	var result = new(big.Int).SetUint64(hi)
+	return result.Lsh(result, 64).Add(result, new(big.Int).SetUint64(lo))
+}
+
+func BenchmarkUint128n(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Uint128n(bound, bound)
+	}
+}
+
+func BenchmarkBigIntn(b *testing.B) {
+	var rng = New()
+	var bound = PermCountBig(52)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.BigIntn(bound)
+	}
+}
+
+func BenchmarkUint32n(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Uint32n(bound)
+	}
+}
+
+func BenchmarkFastUint32n(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N/2; i++ {
+		rng.FastUint32n(bound)
+	}
+}
+
+// Checks that sample stays below bound and is spread uniformly across (up to) 8 equal buckets
+func checkBounded(t *testing.T, name string, bound uint64, sample func() uint64) {
+	t.Helper()
+	var buckets = uint64(8)
+	if bound < buckets {
+		buckets = bound
+	}
+	var want = map[string]float64{}
+	for k := uint64(0); k < buckets; k++ {
+		// Bucket k holds the values v where v*buckets/bound == k
+		var lo = (k*(bound/buckets) + (k*(bound%buckets)+buckets-1)/buckets)
+		var hi = ((k+1)*(bound/buckets) + ((k+1)*(bound%buckets)+buckets-1)/buckets)
+		want[fmt.Sprint(k)] = float64(hi-lo) / float64(bound)
+	}
+	checkFrequencies(t, name, 40000, func() string {
+		var x = sample()
+		if x >= bound {
+			t.Fatalf("%s returned %d", name, x)
+		}
+		if buckets == bound {
+			return fmt.Sprint(x)
+		}
+		// In floating point so that x*buckets can't overflow; rounding
+		// only misplaces values within a few ulps of a bucket edge
+		var bucket = uint64(float64(x) / float64(bound) * float64(buckets))
+		if bucket >= buckets {
+			bucket = buckets - 1
+		}
+		return fmt.Sprint(bucket)
+	}, want)
+}
+
+func TestSmallBoundedInts(t *testing.T) {
+	var rng = newSeededGen()
+	for _, bound := range []uint32{1, 2, 6, 16, 1 << 31, 3 << 30, math.MaxUint32} {
+		var b = bound
+		checkBounded(t, fmt.Sprintf("Uint32n(%d)", b), uint64(b), func() uint64 { return uint64(rng.Uint32n(b)) })
+		var pending []uint32
+		checkBounded(t, fmt.Sprintf("FastUint32n(%d)", b), uint64(b), func() uint64 {
+			if len(pending) == 0 {
+				var x, y = rng.FastUint32n(b)
+				pending = append(pending, x, y)
+			}
+			var x = pending[0]
+			pending = pending[1:]
+			return uint64(x)
+		})
+		if b <= math.MaxInt32 {
+			checkBounded(t, fmt.Sprintf("Int31n(%d)", b), uint64(b), func() uint64 { return uint64(rng.Int31n(int32(b))) })
+		}
+	}
+	for _, bound := range []int64{1, 2, 6, 1 << 40, 3 << 61, math.MaxInt64} {
+		var b = bound
+		checkBounded(t, fmt.Sprintf("Int63n(%d)", b), uint64(b), func() uint64 { return uint64(rng.Int63n(b)) })
+	}
+	// The two halves of FastUint32n() must not be correlated
+	var same = 0
+	for i := 0; i < 10000; i++ {
+		if x, y := rng.FastUint32n(2); x == y {
+			same++
+		}
+	}
+	if math.Abs(float64(same)-5000) > 5*50 {
+		t.Errorf("FastUint32n(2) returned two equal values %d times out of 10000", same)
+	}
+}
+
+func BenchmarkInt64n(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Int64n(bound)
+	}
+}
+
+func TestIntRangeExtremeBounds(t *testing.T) {
+	var rng = New()
+	var sawNegative, sawPositive bool
+	for i := 0; i < 1000; i++ {
+		var x = rng.IntRange(math.MinInt, math.MaxInt)
+		if x == math.MaxInt {
+			t.Fatalf("IntRange(math.MinInt, math.MaxInt) returned math.MaxInt")
+		}
+		sawNegative = sawNegative || x < 0
+		sawPositive = sawPositive || x > 0
+	}
+	if !sawNegative || !sawPositive {
+		t.Fatalf("IntRange(math.MinInt, math.MaxInt) does not cover the full range of int")
+	}
+	for i := 0; i < 100; i++ {
+		if x := rng.IntRange(math.MinInt, math.MinInt+1); x != math.MinInt {
+			t.Fatalf("IntRange(math.MinInt, math.MinInt+1) = %d", x)
+		}
+		if x := rng.Int64Range(math.MaxInt64-1, math.MaxInt64); x != math.MaxInt64-1 {
+			t.Fatalf("Int64Range(math.MaxInt64-1, math.MaxInt64) = %d", x)
+		}
+		if x := rng.Uint64Range(math.MaxUint64-1, math.MaxUint64); x != math.MaxUint64-1 {
+			t.Fatalf("Uint64Range(math.MaxUint64-1, math.MaxUint64) = %d", x)
+		}
+		if x := rng.IntRange(-3, 3); x < -3 || x >= 3 {
+			t.Fatalf("IntRange(-3, 3) = %d", x)
+		}
+	}
+}
+
+func TestIntBetweenExtremeBounds(t *testing.T) {
+	var rng = New()
+	for i := 0; i < 100; i++ {
+		if x := rng.IntBetween(math.MaxInt, math.MaxInt); x != math.MaxInt {
+			t.Fatalf("IntBetween(math.MaxInt, math.MaxInt) = %d", x)
+		}
+		if x := rng.IntBetween(math.MinInt, math.MinInt); x != math.MinInt {
+			t.Fatalf("IntBetween(math.MinInt, math.MinInt) = %d", x)
+		}
+		if x := rng.Uint64Between(math.MaxUint64, math.MaxUint64); x != math.MaxUint64 {
+			t.Fatalf("Uint64Between(math.MaxUint64, math.MaxUint64) = %d", x)
+		}
+	}
+	var seen = map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		seen[rng.IntBetween(math.MaxInt-1, math.MaxInt)] = true
+		seen[rng.IntBetween(math.MinInt, math.MinInt+1)] = true
+	}
+	for _, want := range []int{math.MaxInt - 1, math.MaxInt, math.MinInt, math.MinInt + 1} {
+		if !seen[want] {
+			t.Fatalf("IntBetween never returned %d", want)
+		}
+	}
+	var sawNegative, sawPositive bool
+	for i := 0; i < 1000; i++ {
+		var x = rng.Int64Between(math.MinInt64, math.MaxInt64)
+		sawNegative = sawNegative || x < 0
+		sawPositive = sawPositive || x > 0
+	}
+	if !sawNegative || !sawPositive {
+		t.Fatalf("Int64Between(math.MinInt64, math.MaxInt64) does not cover the full range of int64")
+	}
+}
+
+func TestCheckedRejectsBadArguments(t *testing.T) {
+	var rng = NewChecked(New())
+	var errs = []error{
+		second(rng.Uint64bits(0)),
+		second(rng.Uint64bits(65)),
+		second(rng.Uint64n(0)),
+		second(rng.Uint32n(0)),
+		second(rng.Intn(-5)),
+		second(rng.Int64n(0)),
+		second(rng.Int32n(-1)),
+		second(rng.IntRange(3, 3)),
+		second(rng.Int64Range(5, -5)),
+		second(rng.Uint64Range(1, 0)),
+		second(rng.IntBetween(1, 0)),
+		second(rng.Odds(1, 0)),
+		second(rng.Odds(2, 1)),
+		second(rng.BigIntn(big.NewInt(0))),
+		second(rng.Perm(-1)),
+		second(rng.Combination(3, 4)),
+		second(rng.Subset(3, math.NaN())),
+		second(rng.Subset(3, 1.5)),
+		second(rng.Partition(-1)),
+		second(rng.Composition(-3)),
+		second(rng.RandomOrthogonal(-1)),
+	}
+	for i, err := range errs {
+		var argumentErr *ArgumentError
+		if !errors.Is(err, ErrInvalidArgument) || !errors.As(err, &argumentErr) {
+			t.Errorf("case %d: got error %v, want an *ArgumentError", i, err)
+		}
+	}
+
+	if x, err := rng.IntRange(math.MinInt, math.MaxInt); err != nil || x == math.MaxInt {
+		t.Errorf("IntRange(math.MinInt, math.MaxInt) = %d, %v", x, err)
+	}
+	if x, err := rng.IntBetween(7, 7); err != nil || x != 7 {
+		t.Errorf("IntBetween(7, 7) = %d, %v", x, err)
+	}
+	if _, err := rng.Uint64bits(64); err != nil {
+		t.Errorf("Uint64bits(64) returned error %v", err)
+	}
+	if _, _, err := rng.Triangle([2]float64{0, 0}, [2]float64{1, math.NaN()}, [2]float64{0, 1}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Triangle with a NaN vertex returned error %v", err)
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
+
+func BenchmarkFullFloat64(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.FullFloat64()
+	}
+}
+
+func BenchmarkFullFloat32(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.FullFloat32()
+	}
+}
+
+func TestFullFloats(t *testing.T) {
+	var rng = newSeededGen()
+	const n = 400000
+	for _, c := range []struct {
+		name   string
+		sample func() float64
+	}{
+		{"FullFloat64", rng.FullFloat64},
+		{"FullFloat32", func() float64 { return float64(rng.FullFloat32()) }},
+	} {
+		var sum float64
+		var below [17]int
+		for i := 0; i < n; i++ {
+			var x = c.sample()
+			if x < 0 || x >= 1 {
+				t.Fatalf("%s returned %v, outside of [0, 1)", c.name, x)
+			}
+			sum += x
+			for k := 1; k < len(below) && x < math.Ldexp(1, -k); k++ {
+				below[k]++
+			}
+		}
+		if mean := sum / n; math.Abs(mean-0.5) > 5*math.Sqrt(1.0/12/n) {
+			t.Errorf("%s: sample mean %v, want 0.5", c.name, mean)
+		}
+		// Values below 2^-k should make up a fraction 2^-k of the samples
+		for k := 1; k < len(below); k++ {
+			var p = math.Ldexp(1, -k)
+			if math.Abs(float64(below[k])-n*p) > 5*math.Sqrt(n*p*(1-p))+1 {
+				t.Errorf("%s: %d samples below 2^-%d, want about %.1f", c.name, below[k], k, n*p)
+			}
+		}
+	}
+	// Small values keep their full precision instead of being
+	// rounded to the multiples of 2^-53 that Float64() returns
+	var smallest = 1.0
+	for i := 0; i < n; i++ {
+		smallest = math.Min(smallest, rng.FullFloat64())
+	}
+	if smallest == 0 || smallest*math.Ldexp(1, 53) == math.Trunc(smallest*math.Ldexp(1, 53)) {
+		t.Errorf("FullFloat64's smallest sample %v is a multiple of 2^-53", smallest)
+	}
+}
+
+func TestFloatRangeNeverReturnsUpperBound(t *testing.T) {
+	var rng = New()
+	// The spacing between representable values at these magnitudes is
+	// large compared to the width of the interval, so without rerolling
+	// roughly one in every sixteen results would round up to upperBound
+	var lowerBound, upperBound = 1e16, 1e16 + 16
+	var lowerBound32, upperBound32 float32 = 1e8, 1e8 + 64
+	for i := 0; i < 10000; i++ {
+		if x := rng.Float64Range(lowerBound, upperBound); x < lowerBound || x >= upperBound {
+			t.Fatalf("Float64Range(%v, %v) = %v", lowerBound, upperBound, x)
+		}
+		if x := rng.Float32Range(lowerBound32, upperBound32); x < lowerBound32 || x >= upperBound32 {
+			t.Fatalf("Float32Range(%v, %v) = %v", lowerBound32, upperBound32, x)
+		}
+		if x := rng.Float64Range(-math.MaxFloat64, math.MaxFloat64); math.IsNaN(x) || math.IsInf(x, 0) {
+			t.Fatalf("Float64Range(-math.MaxFloat64, math.MaxFloat64) = %v", x)
+		}
+		if x := rng.Float64Open(); x <= 0 || x >= 1 {
+			t.Fatalf("Float64Open() = %v", x)
+		}
+		if x := rng.Float64OpenClosed(); x <= 0 || x > 1 {
+			t.Fatalf("Float64OpenClosed() = %v", x)
+		}
+		if x := rng.Float32Closed(); x < 0 || x > 1 {
+			t.Fatalf("Float32Closed() = %v", x)
+		}
+	}
+}
+
+func TestFloatRangeDegenerateBounds(t *testing.T) {
+	var rng = New()
+	var inf, nan = math.Inf(1), math.NaN()
+	if x := rng.Float64Range(3, 3); x != 3 {
+		t.Errorf("Float64Range(3, 3) = %v, want 3", x)
+	}
+	if x := rng.Float32Range(-2, -2); x != -2 {
+		t.Errorf("Float32Range(-2, -2) = %v, want -2", x)
+	}
+	if x := rng.Float64Range(inf, inf); x != inf {
+		t.Errorf("Float64Range(+Inf, +Inf) = %v, want +Inf", x)
+	}
+	if x := (Uniform{Lo: 3, Hi: 3}).Sample(rng); x != 3 {
+		t.Errorf("Uniform{3, 3}.Sample() = %v, want 3", x)
+	}
+	for _, bounds := range [][2]float64{{nan, 1}, {0, nan}, {2, 1}, {-inf, 0}, {0, inf}, {-inf, inf}} {
+		if x := rng.Float64Range(bounds[0], bounds[1]); !math.IsNaN(x) {
+			t.Errorf("Float64Range(%v, %v) = %v, want NaN", bounds[0], bounds[1], x)
+		}
+		if x := rng.Float32Range(float32(bounds[0]), float32(bounds[1])); !math.IsNaN(float64(x)) {
+			t.Errorf("Float32Range(%v, %v) = %v, want NaN", bounds[0], bounds[1], x)
+		}
+	}
+}
+
+func BenchmarkNormalFloat32(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N/2; i++ {
+		rng.NormalFloat32()
+	}
+}
+
+func BenchmarkExponentialFloat32(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.ExponentialFloat32()
+	}
+}
+
+func TestFloat32Distributions(t *testing.T) {
+	var rng = newSeededGen()
+	checkMoments(t, "NormalFloat32 (first)", func() float64 {
+		var x, _ = rng.NormalFloat32()
+		return float64(x)
+	}, 0, 1)
+	checkMoments(t, "NormalFloat32 (second)", func() float64 {
+		var _, y = rng.NormalFloat32()
+		return float64(y)
+	}, 0, 1)
+	checkMoments(t, "ExponentialFloat32", func() float64 { return float64(rng.ExponentialFloat32()) }, 1, 1)
+}
+
+// Sample size used by tests that compare empirical and analytic CDFs
+const ksSamples = 20000
+
+// Critical value of the Kolmogorov-Smirnov statistic at the 0.1% significance level
+var ksCritical = 1.95 / math.Sqrt(ksSamples)
+
+// Returns the Kolmogorov-Smirnov statistic: the largest distance
+// between the empirical CDF of samples and cdf
+func ksStatistic(samples []float64, cdf func(float64) float64) float64 {
+	sort.Float64s(samples)
+	var statistic float64
+	var n = float64(len(samples))
+	for i, x := range samples {
+		var p = cdf(x)
+		statistic = math.Max(statistic, math.Max(p-float64(i)/n, float64(i+1)/n-p))
+	}
+	return statistic
+}
+
+// Fails the test if the empirical CDF of sample() doesn't match cdf
+func checkDistribution(t *testing.T, name string, sample func() float64, cdf func(float64) float64) {
+	t.Helper()
+	var samples = make([]float64, ksSamples)
+	for i := range samples {
+		samples[i] = sample()
+	}
+	if statistic := ksStatistic(samples, cdf); statistic > ksCritical {
+		t.Errorf("%s: Kolmogorov-Smirnov statistic %.4f exceeds critical value %.4f", name, statistic, ksCritical)
+	}
+}
+
+// Returns a *Gen with a fixed seed so that statistical tests are reproducible
+func newSeededGen() *Gen {
+	var rng = New()
+	rng.ManualSeed(0x5eed)
+	return rng
+}
+
+func TestContinuousDistributions(t *testing.T) {
+	var rng = newSeededGen()
+	checkDistribution(t, "Lognormal", func() float64 { return rng.Lognormal(0.5, 0.8) }, func(x float64) float64 {
+		return 0.5 * math.Erfc(-(math.Log(x)-0.5)/(0.8*math.Sqrt2))
+	})
+	checkDistribution(t, "Weibull", func() float64 { return rng.Weibull(1.5, 2) }, func(x float64) float64 {
+		return 1 - math.Exp(-math.Pow(x/2, 1.5))
+	})
+	checkDistribution(t, "Pareto", func() float64 { return rng.Pareto(3, 2.5) }, func(x float64) float64 {
+		return 1 - math.Pow(3/x, 2.5)
+	})
+	checkDistribution(t, "Cauchy", func() float64 { return rng.Cauchy(-1, 0.5) }, func(x float64) float64 {
+		return 0.5 + math.Atan((x+1)/0.5)/math.Pi
+	})
+	checkDistribution(t, "Laplace", func() float64 { return rng.Laplace(2, 3) }, func(x float64) float64 {
+		if x < 2 {
+			return 0.5 * math.Exp((x-2)/3)
+		}
+		return 1 - 0.5*math.Exp(-(x-2)/3)
+	})
+	checkDistribution(t, "Logistic", func() float64 { return rng.Logistic(1, 2) }, func(x float64) float64 {
+		return 1 / (1 + math.Exp(-(x-1)/2))
+	})
+	checkDistribution(t, "Gumbel", func() float64 { return rng.Gumbel(0.5, 2) }, func(x float64) float64 {
+		return math.Exp(-math.Exp(-(x - 0.5) / 2))
+	})
+}
+
+func TestCheckedDistributionsRejectBadArguments(t *testing.T) {
+	var rng = NewChecked(New())
+	var errs = []error{
+		second(rng.Lognormal(math.NaN(), 1)),
+		second(rng.Weibull(0, 1)),
+		second(rng.Pareto(1, -2)),
+		second(rng.Cauchy(0, math.Inf(1))),
+		second(rng.Laplace(math.Inf(-1), 1)),
+		second(rng.Logistic(0, 0)),
+		second(rng.Gumbel(0, -1)),
+		second(rng.TruncatedNormal(0, 1, math.Inf(1), math.Inf(1))),
+		second(rng.TruncatedNormal(0, 1, math.Inf(-1), math.Inf(-1))),
+		second(rng.TruncatedNormal(0, 1, 0, math.NaN())),
+	}
+	for i, err := range errs {
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("case %d: got error %v, want an *ArgumentError", i, err)
+		}
+	}
+	if _, err := rng.Weibull(2, 3); err != nil {
+		t.Errorf("Weibull(2, 3) returned error %v", err)
+	}
+	if _, err := rng.TruncatedNormal(0, 1, math.Inf(-1), math.Inf(1)); err != nil {
+		t.Errorf("TruncatedNormal(0, 1, -Inf, +Inf) returned error %v", err)
+	}
+}
+
+// Fails the test if the sample mean or variance of sample() is too far from mean/variance
+func checkMoments(t *testing.T, name string, sample func() float64, mean, variance float64) {
+	t.Helper()
+	const n = 200000
+	var sum, sumSquares float64
+	for i := 0; i < n; i++ {
+		var x = sample()
+		sum += x
+		sumSquares += x * x
+	}
+	var sampleMean = sum / n
+	var sampleVariance = sumSquares/n - sampleMean*sampleMean
+	if math.Abs(sampleMean-mean) > 5*math.Sqrt(variance/n) {
+		t.Errorf("%s: sample mean %.4f, want %.4f", name, sampleMean, mean)
+	}
+	if math.Abs(sampleVariance-variance) > 0.05*variance {
+		t.Errorf("%s: sample variance %.4f, want %.4f", name, sampleVariance, variance)
+	}
+}
+
+func TestGammaFamilyDistributions(t *testing.T) {
+	var rng = newSeededGen()
+	checkDistribution(t, "Gamma(2, 1.5)", func() float64 { return rng.Gamma(2, 1.5) }, func(x float64) float64 {
+		return 1 - math.Exp(-x/1.5)*(1+x/1.5)
+	})
+	checkDistribution(t, "ChiSquared(1)", func() float64 { return rng.ChiSquared(1) }, func(x float64) float64 {
+		return math.Erf(math.Sqrt(x / 2))
+	})
+	checkDistribution(t, "StudentT(2)", func() float64 { return rng.StudentT(2) }, func(x float64) float64 {
+		return 0.5 + x/(2*math.Sqrt(2+x*x))
+	})
+	checkMoments(t, "Gamma(0.3, 2)", func() float64 { return rng.Gamma(0.3, 2) }, 0.6, 1.2)
+	checkMoments(t, "StudentT(10)", func() float64 { return rng.StudentT(10) }, 0, 10.0/8)
+	checkMoments(t, "FDist(5, 20)", func() float64 { return rng.FDist(5, 20) }, 20.0/18, 2*20*20*23/(5*18*18*16.0))
+	checkMoments(t, "NoncentralChiSquared(3, 2)", func() float64 { return rng.NoncentralChiSquared(3, 2) }, 5, 14)
+	checkMoments(t, "NoncentralChiSquared(0.5, 4)", func() float64 { return rng.NoncentralChiSquared(0.5, 4) }, 4.5, 17)
+	checkMoments(t, "Poisson(3)", func() float64 { return float64(rng.Poisson(3)) }, 3, 3)
+	checkMoments(t, "Poisson(250)", func() float64 { return float64(rng.Poisson(250)) }, 250, 250)
+}
+
+func BenchmarkGamma(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Gamma(2.5, 1)
+	}
+}
+
+func BenchmarkPoisson(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Poisson(100)
+	}
+}
+
+func TestTruncatedDistributions(t *testing.T) {
+	var rng = newSeededGen()
+	// Normal survival function, which stays accurate far out in the right tail
+	var survival = func(x float64) float64 { return math.Erfc(x/math.Sqrt2) / 2 }
+	for _, bounds := range [][2]float64{{-1, 2}, {-0.1, 0.2}, {-3, 3}, {0, math.Inf(1)}, {3, math.Inf(1)}, {8, 8.5}, {5, 5.01}, {math.Inf(-1), -4}, {-6, -5.5}} {
+		var a, b = bounds[0], bounds[1]
+		const mean, stddev = 10, 3
+		checkDistribution(t, fmt.Sprintf("TruncatedNormal(%v, %v)", a, b), func() float64 {
+			var x = rng.TruncatedNormal(mean, stddev, mean+stddev*a, mean+stddev*b)
+			if x < mean+stddev*a || x > mean+stddev*b {
+				t.Fatalf("TruncatedNormal returned %v outside of [%v, %v]", x, mean+stddev*a, mean+stddev*b)
+			}
+			return (x - mean) / stddev
+		}, func(x float64) float64 {
+			if b <= 0 {
+				// Mirrored so that the subtraction doesn't cancel
+				return (survival(-x) - survival(-a)) / (survival(-b) - survival(-a))
+			}
+			return (survival(a) - survival(x)) / (survival(a) - survival(b))
+		})
+	}
+	for _, upperBound := range []float64{0.1, 2, math.Inf(1)} {
+		const lambda = 1.5
+		checkDistribution(t, fmt.Sprintf("TruncatedExponential(%v, %v)", lambda, upperBound), func() float64 {
+			return rng.TruncatedExponential(lambda, upperBound)
+		}, func(x float64) float64 {
+			return math.Expm1(-lambda*x) / math.Expm1(-lambda*upperBound)
+		})
+	}
+}
+
+func BenchmarkTruncatedNormalTail(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.TruncatedNormal(0, 1, 6, math.Inf(1))
+	}
+}
+
+func TestMultivariateNormal(t *testing.T) {
+	var rng = newSeededGen()
+	var mean = []float64{1, -2, 0.5}
+	for _, covariance := range [][][]float64{
+		{{4, 1.2, -0.6}, {1.2, 1, 0.3}, {-0.6, 0.3, 2}},
+		// Only positive semi-definite: the third component is the sum of the first two
+		{{1, 0.5, 1.5}, {0.5, 2, 2.5}, {1.5, 2.5, 4}},
+	} {
+		var dist, err = NewMultivariateNormal(mean, covariance)
+		if err != nil {
+			t.Fatalf("NewMultivariateNormal(%v) returned error %v", covariance, err)
+		}
+		const n = 200000
+		var sample = make([]float64, len(mean))
+		var sums = make([]float64, len(mean))
+		var products = make([][]float64, len(mean))
+		for i := range products {
+			products[i] = make([]float64, len(mean))
+		}
+		for k := 0; k < n; k++ {
+			dist.Sample(rng, sample)
+			for i := range sample {
+				sums[i] += sample[i]
+				for j := range sample {
+					products[i][j] += sample[i] * sample[j]
+				}
+			}
+		}
+		for i := range mean {
+			if got := sums[i] / n; math.Abs(got-mean[i]) > 0.02 {
+				t.Errorf("sample mean[%d] = %.4f, want %v", i, got, mean[i])
+			}
+			for j := range mean {
+				var got = products[i][j]/n - (sums[i]/n)*(sums[j]/n)
+				if math.Abs(got-covariance[i][j]) > 0.05 {
+					t.Errorf("sample covariance[%d][%d] = %.4f, want %v", i, j, got, covariance[i][j])
+				}
+			}
+		}
+	}
+
+	for _, covariance := range [][][]float64{
+		{{1, 0}, {0, -1}},
+		{{1, 2}, {2, 1}},
+		{{1, 0.5}, {0.4, 1}},
+		{{1, 0}},
+	} {
+		if _, err := NewMultivariateNormal([]float64{0, 0}, covariance); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewMultivariateNormal(%v) returned error %v, want an *ArgumentError", covariance, err)
+		}
+	}
+}
+
+func BenchmarkMultivariateNormal(b *testing.B) {
+	var rng = New()
+	var dist, _ = NewMultivariateNormal([]float64{0, 0, 0, 0}, [][]float64{
+		{2, 0.5, 0, 0.1},
+		{0.5, 1, 0.2, 0},
+		{0, 0.2, 1, 0.3},
+		{0.1, 0, 0.3, 3},
+	})
+	var sample = make([]float64, dist.Dimension())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dist.Sample(rng, sample)
+	}
+}
+
+func TestBinomialFamilyDistributions(t *testing.T) {
+	var rng = newSeededGen()
+	checkDistribution(t, "Beta(2, 2)", func() float64 { return rng.Beta(2, 2) }, func(x float64) float64 {
+		return x * x * (3 - 2*x)
+	})
+	checkMoments(t, "Binomial(20, 0.3)", func() float64 { return float64(rng.Binomial(20, 0.3)) }, 6, 4.2)
+	checkMoments(t, "Binomial(1000, 0.9)", func() float64 { return float64(rng.Binomial(1000, 0.9)) }, 900, 90)
+	checkMoments(t, "Binomial(1e7, 0.25)", func() float64 { return float64(rng.Binomial(1e7, 0.25)) }, 2.5e6, 1.875e6)
+
+	var alpha = []float64{0.5, 2, 7.5}
+	var means = make([]float64, len(alpha))
+	const n = 100000
+	for i := 0; i < n; i++ {
+		var vector = rng.Dirichlet(alpha)
+		var sum float64
+		for j, x := range vector {
+			means[j] += x / n
+			sum += x
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("Dirichlet(%v) = %v, which sums to %v", alpha, vector, sum)
+		}
+	}
+	for i := range alpha {
+		if want := alpha[i] / 10; math.Abs(means[i]-want) > 0.005 {
+			t.Errorf("Dirichlet(%v) component %d has sample mean %.4f, want %.4f", alpha, i, means[i], want)
+		}
+	}
+
+	// Small shapes, whose gamma variates mostly underflow to 0
+	for _, alpha := range [][]float64{{0.001, 0.001}, {0.001, 0.001, 0.001}, {1e-3, 0.5, 3}} {
+		for i := 0; i < 10000; i++ {
+			var vector = rng.Dirichlet(alpha)
+			var sum float64
+			for _, x := range vector {
+				sum += x
+			}
+			if math.IsNaN(sum) || math.Abs(sum-1) > 1e-12 {
+				t.Fatalf("Dirichlet(%v) = %v, which sums to %v", alpha, vector, sum)
+			}
+		}
+	}
+	for _, shape := range []float64{0.001, 0.005} {
+		var mean float64
+		for i := 0; i < n; i++ {
+			var x = rng.Beta(shape, shape)
+			if !(x >= 0 && x <= 1) {
+				t.Fatalf("Beta(%v, %v) = %v", shape, shape, x)
+			}
+			mean += x / n
+		}
+		if math.Abs(mean-0.5) > 0.01 {
+			t.Errorf("Beta(%v, %v) has sample mean %.4f, want 0.5", shape, shape, mean)
+		}
+	}
+	checkMoments(t, "Beta(0.3, 0.6)", func() float64 { return rng.Beta(0.3, 0.6) }, 1.0/3, 0.3*0.6/(0.9*0.9*1.9))
+
+	var p = []float64{1, 3, 0, 6}
+	const trials = 1000
+	for i := range p {
+		var mean = trials * p[i] / 10
+		checkMoments(t, fmt.Sprintf("Multinomial(%d, %v)[%d]", trials, p, i), func() float64 {
+			return float64(rng.Multinomial(trials, p)[i])
+		}, mean, mean*(1-p[i]/10))
+	}
+	for i := 0; i < 1000; i++ {
+		var sum = 0
+		for _, count := range rng.Multinomial(12345, p) {
+			sum += count
+		}
+		if sum != 12345 {
+			t.Fatalf("Multinomial(12345, %v) counts sum to %d", p, sum)
+		}
+	}
+}
+
+func BenchmarkBinomial(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.Binomial(1000000, 0.3)
+	}
+}
+
+func TestUniformPointSamplers(t *testing.T) {
+	var rng = newSeededGen()
+	checkDistribution(t, "UnitCircle angle", func() float64 {
+		var x, y = rng.UnitCircle()
+		if math.Abs(math.Hypot(x, y)-1) > 1e-12 {
+			t.Fatalf("UnitCircle() = (%v, %v) is not on the unit circle", x, y)
+		}
+		return math.Atan2(y, x)
+	}, func(angle float64) float64 { return (angle + math.Pi) / (2 * math.Pi) })
+	// Archimedes: the height of a uniform point on a sphere is uniform
+	checkDistribution(t, "UnitSphere3 height", func() float64 {
+		var x, y, z = rng.UnitSphere3()
+		if math.Abs(x*x+y*y+z*z-1) > 1e-12 {
+			t.Fatalf("UnitSphere3() = (%v, %v, %v) is not on the unit sphere", x, y, z)
+		}
+		return z
+	}, func(z float64) float64 { return (z + 1) / 2 })
+	checkDistribution(t, "UnitDisk radius", func() float64 { return math.Hypot(rng.UnitDisk()) }, func(r float64) float64 {
+		return r * r
+	})
+	checkDistribution(t, "UnitBall3 radius", func() float64 {
+		var x, y, z = rng.UnitBall3()
+		return math.Sqrt(x*x + y*y + z*z)
+	}, func(r float64) float64 { return r * r * r })
+	checkDistribution(t, "Annulus radius", func() float64 { return math.Hypot(rng.Annulus(1, 3)) }, func(r float64) float64 {
+		return (r*r - 1) / 8
+	})
+
+	var point = make([]float64, 5)
+	checkDistribution(t, "UnitBall radius in 5D", func() float64 {
+		var norm float64
+		for _, x := range rng.UnitBall(point) {
+			norm += x * x
+		}
+		return math.Sqrt(norm)
+	}, func(r float64) float64 { return math.Pow(r, 5) })
+	// The first coordinate of a point on the simplex in n dimensions is Beta(1, n-1)
+	checkDistribution(t, "Simplex coordinate in 5D", func() float64 { return rng.Simplex(point)[0] }, func(x float64) float64 {
+		return 1 - math.Pow(1-x, 4)
+	})
+	for i := 0; i < 1000; i++ {
+		var norm float64
+		for _, x := range rng.UnitSphere(point) {
+			norm += x * x
+		}
+		if math.Abs(norm-1) > 1e-12 {
+			t.Fatalf("UnitSphere() = %v is not on the unit sphere", point)
+		}
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	var rng = newSeededGen()
+	// Clockwise L shape made of a 2x1 rectangle and a 1x1 square on top of its left half
+	var polygon, err = NewPolygon([][2]float64{{0, 0}, {0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}})
+	if err != nil {
+		t.Fatalf("NewPolygon() returned error %v", err)
+	}
+	const n = 300000
+	var upper = 0
+	for i := 0; i < n; i++ {
+		var x, y = polygon.Sample(rng)
+		if x < 0 || y < 0 || x > 2 || y > 2 || (x > 1 && y > 1) {
+			t.Fatalf("Polygon.Sample() = (%v, %v) is outside of the polygon", x, y)
+		}
+		if y > 1 {
+			upper++
+		}
+	}
+	// The square on top holds one third of the area
+	if got := float64(upper) / n; math.Abs(got-1.0/3) > 0.005 {
+		t.Errorf("fraction of samples in the upper square = %.4f, want 0.3333", got)
+	}
+	for _, vertices := range [][][2]float64{
+		{{0, 0}, {1, 1}},
+		{{0, 0}, {1, 1}, {2, 2}},
+		{{0, 0}, {math.NaN(), 1}, {1, 0}},
+	} {
+		if _, err := NewPolygon(vertices); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewPolygon(%v) returned error %v, want an *ArgumentError", vertices, err)
+		}
+	}
+}
+
+func TestRandomRotations(t *testing.T) {
+	var rng = newSeededGen()
+	// The first component of a uniform point on the 3-sphere has density proportional to sqrt(1 - w^2)
+	checkDistribution(t, "UniformQuaternion w", func() float64 {
+		var q = rng.UniformQuaternion()
+		if norm := q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3]; math.Abs(norm-1) > 1e-12 {
+			t.Fatalf("UniformQuaternion() = %v is not a unit quaternion", q)
+		}
+		return q[0]
+	}, func(w float64) float64 {
+		return 0.5 + (w*math.Sqrt(1-w*w)+math.Asin(w))/math.Pi
+	})
+	// Each column of a Haar distributed orthogonal matrix is a uniform
+	// point on the sphere, whose height in 3D is uniform (Archimedes)
+	checkDistribution(t, "RandomOrthogonal(3) entry", func() float64 {
+		return rng.RandomOrthogonal(3)[2][1]
+	}, func(x float64) float64 { return (x + 1) / 2 })
+
+	for _, n := range []int{1, 2, 5, 8} {
+		var q = rng.RandomOrthogonal(n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				var dot float64
+				for k := 0; k < n; k++ {
+					dot += q[k][i] * q[k][j]
+				}
+				var want = 0.0
+				if i == j {
+					want = 1
+				}
+				if math.Abs(dot-want) > 1e-12 {
+					t.Fatalf("RandomOrthogonal(%d) = %v is not orthogonal", n, q)
+				}
+			}
+		}
+	}
+}
+
+func TestZipf(t *testing.T) {
+	var rng = newSeededGen()
+	for _, params := range []struct {
+		s, v float64
+		imax uint64
+	}{{0.5, 1, 20}, {1, 1, 20}, {1.1, 2.5, 50}, {2, 1, 10}, {3.5, 1, 1000}} {
+		var zipf, err = NewZipf(rng, params.s, params.v, params.imax)
+		if err != nil {
+			t.Fatalf("NewZipf(%+v) returned error %v", params, err)
+		}
+		var pmf = make([]float64, params.imax+1)
+		var total float64
+		for k := range pmf {
+			pmf[k] = math.Pow(params.v+float64(k), -params.s)
+			total += pmf[k]
+		}
+		const n = 200000
+		var counts = make([]float64, len(pmf))
+		for i := 0; i < n; i++ {
+			var k = zipf.Uint64()
+			if k > params.imax {
+				t.Fatalf("Zipf(%+v).Uint64() = %d", params, k)
+			}
+			counts[k]++
+		}
+		for k := range pmf {
+			var p = pmf[k] / total
+			// The extra 3/n allows for a stray hit on values with negligible probability
+			if math.Abs(counts[k]/n-p) > 5*math.Sqrt(p*(1-p)/n)+3.0/n {
+				t.Errorf("Zipf(%+v): frequency of %d is %.5f, want %.5f", params, k, counts[k]/n, p)
+			}
+		}
+	}
+	for _, params := range [][2]float64{{0, 1}, {-1, 1}, {1, 0.5}, {math.NaN(), 1}, {1, math.Inf(1)}} {
+		if _, err := NewZipf(rng, params[0], params[1], 10); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewZipf(%v, %v) returned error %v, want an *ArgumentError", params[0], params[1], err)
+		}
+	}
+
+	checkMoments(t, "YuleSimon(5)", func() float64 { return float64(rng.YuleSimon(5)) }, 1.25, 25.0/48)
+}
+
+func BenchmarkZipf(b *testing.B) {
+	var rng = New()
+	var zipf, _ = NewZipf(rng, 1.1, 1, 1000000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		zipf.Uint64()
+	}
+}
+
+// Returns the CDF of the (not necessarily normalized) density on the interval
+// [lowerBound, upperBound], integrated numerically with the trapezoid rule
+func numericCDF(density func(float64) float64, lowerBound, upperBound float64) func(float64) float64 {
+	const steps = 20000
+	var width = (upperBound - lowerBound) / steps
+	var cumulative = make([]float64, steps+1)
+	for i := 1; i <= steps; i++ {
+		var x = lowerBound + float64(i)*width
+		cumulative[i] = cumulative[i-1] + (density(x-width)+density(x))*width/2
+	}
+	return func(x float64) float64 {
+		var position = (x - lowerBound) / width
+		if position <= 0 {
+			return 0
+		}
+		if position >= steps {
+			return 1
+		}
+		var i = int(position)
+		var fraction = position - float64(i)
+		return (cumulative[i] + fraction*(cumulative[i+1]-cumulative[i])) / cumulative[steps]
+	}
+}
+
+func TestCircularDistributions(t *testing.T) {
+	var rng = newSeededGen()
+	var inRange = func(name string, angle float64) float64 {
+		if angle < -math.Pi || angle >= math.Pi {
+			t.Fatalf("%s returned %v, outside of [-pi, pi)", name, angle)
+		}
+		return angle
+	}
+	for _, params := range [][2]float64{{0, 0}, {1, 0.5}, {3, 4}, {-2, 50}} {
+		var mu, kappa = params[0], params[1]
+		var name = fmt.Sprintf("VonMises(%v, %v)", mu, kappa)
+		checkDistribution(t, name, func() float64 { return inRange(name, rng.VonMises(mu, kappa)) },
+			numericCDF(func(x float64) float64 { return math.Exp(kappa * (math.Cos(x-mu) - 1)) }, -math.Pi, math.Pi))
+	}
+	// Far past the point where the normal approximation takes over
+	checkDistribution(t, "VonMises(0.5, 1e12)", func() float64 {
+		return (rng.VonMises(0.5, 1e12) - 0.5) * 1e6
+	}, func(x float64) float64 { return math.Erfc(-x/math.Sqrt2) / 2 })
+	// Just below it, where Best and Fisher's method has to hold up on its own
+	checkDistribution(t, "VonMises(-0.5, 1e7)", func() float64 {
+		return (rng.VonMises(-0.5, 1e7) + 0.5) * math.Sqrt(1e7)
+	}, func(x float64) float64 { return math.Erfc(-x/math.Sqrt2) / 2 })
+
+	for _, params := range [][2]float64{{0, 0.3}, {3, 1.5}} {
+		var mu, gamma = params[0], params[1]
+		var rho = math.Exp(-gamma)
+		var name = fmt.Sprintf("WrappedCauchy(%v, %v)", mu, gamma)
+		checkDistribution(t, name, func() float64 { return inRange(name, rng.WrappedCauchy(mu, gamma)) },
+			numericCDF(func(x float64) float64 { return 1 / (1 + rho*rho - 2*rho*math.Cos(x-mu)) }, -math.Pi, math.Pi))
+	}
+	for _, params := range [][2]float64{{0, 0.5}, {-3, 2}} {
+		var mu, sigma = params[0], params[1]
+		var name = fmt.Sprintf("WrappedNormal(%v, %v)", mu, sigma)
+		checkDistribution(t, name, func() float64 { return inRange(name, rng.WrappedNormal(mu, sigma)) },
+			numericCDF(func(x float64) float64 {
+				var sum float64
+				for k := -10; k <= 10; k++ {
+					var z = (x - mu + 2*math.Pi*float64(k)) / sigma
+					sum += math.Exp(-z * z / 2)
+				}
+				return sum
+			}, -math.Pi, math.Pi))
+	}
+}
+
+func BenchmarkVonMises(b *testing.B) {
+	var rng = New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rng.VonMises(0, 2)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	var rng = newSeededGen()
+	checkDistribution(t, "InverseCDF(exponential)", func() float64 {
+		return InverseCDF(rng, func(p float64) float64 { return -math.Log1p(-p) / 2 })
+	}, func(x float64) float64 { return -math.Expm1(-2 * x) })
+
+	var halfNormalCDF = func(x float64) float64 { return math.Erf(x / math.Sqrt2) }
+	checkDistribution(t, "Rejection(half-normal)", func() float64 {
+		return Rejection(rng, func(rng *Gen) (float64, float64) {
+			var x = rng.Exponential()
+			return x, math.Exp(-x)
+		}, func(x float64) float64 {
+			return math.Exp(-x * x / 2)
+		}, math.Exp(0.5))
+	}, halfNormalCDF)
+
+	// Piecewise linear over its bins, so the table is exact
+	var triangle, err = NewTabulated(func(x float64) float64 { return 1 - math.Abs(x) }, -1, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDistribution(t, "Tabulated(triangle)", func() float64 { return triangle.Sample(rng) }, func(x float64) float64 {
+		if x < 0 {
+			return (1 + x) * (1 + x) / 2
+		}
+		return 1 - (1-x)*(1-x)/2
+	})
+	// Curved, so only accurate with enough bins
+	squared, err := NewTabulated(func(x float64) float64 { return 3 * x * x }, 0, 1, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDistribution(t, "Tabulated(3x^2)", func() float64 {
+		var x = squared.Sample(rng)
+		if x < 0 || x > 1 {
+			t.Fatalf("Tabulated(3x^2) returned %v, outside of [0, 1]", x)
+		}
+		return x
+	}, func(x float64) float64 { return x * x * x })
+	// The outer bins ramp down to zero at 1 and 2, and the bin between them must never be chosen
+	gapped, err := NewTabulated(func(x float64) float64 {
+		if x >= 1 && x <= 2 {
+			return 0
+		}
+		return 1
+	}, 0, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10000; i++ {
+		if x := gapped.Sample(rng); x > 1 && x < 2 {
+			t.Fatalf("Tabulated sampled %v from a bin with zero density", x)
+		}
+	}
+
+	for _, c := range []struct {
+		density    func(float64) float64
+		lo, hi     float64
+		bins       int
+		wantReason string
+	}{
+		{func(float64) float64 { return 1 }, 1, 1, 10, "upperBound"},
+		{func(float64) float64 { return 1 }, 0, math.Inf(1), 10, "upperBound"},
+		{func(float64) float64 { return 1 }, 0, 1, 0, "bins"},
+		{func(x float64) float64 { return x - 0.5 }, 0, 1, 10, "density(0)"},
+		{func(float64) float64 { return math.NaN() }, 0, 1, 10, "density(0)"},
+		{func(float64) float64 { return 0 }, 0, 1, 10, "density"},
+	} {
+		var _, err = NewTabulated(c.density, c.lo, c.hi, c.bins)
+		var argErr *ArgumentError
+		if !errors.As(err, &argErr) || argErr.Argument != c.wantReason {
+			t.Errorf("NewTabulated(%v, %v, %v) returned %v, want an error about %s", c.lo, c.hi, c.bins, err, c.wantReason)
+		}
+	}
+}
+
+func TestAliasTable(t *testing.T) {
+	var rng = newSeededGen()
+	var weights = []float64{0, 1, 2, 0, 5, 0.5, 1.5}
+	var table = newAliasTable(weights)
+	var total float64
+	for i, weight := range weights {
+		total += weight
+		if weight == 0 && (table.probability[i] != 0 || weights[table.alias[i]] == 0) {
+			t.Errorf("index %d has weight 0 but is kept with probability %v, alias %d", i, table.probability[i], table.alias[i])
+		}
+	}
+	const n = 200000
+	var counts = make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		counts[table.sample(rng)]++
+	}
+	for i, weight := range weights {
+		var p = weight / total
+		var sigma = math.Sqrt(n * p * (1 - p))
+		if math.Abs(float64(counts[i])-n*p) > 5*sigma+1e-9 {
+			t.Errorf("index %d chosen %d times, want about %v", i, counts[i], n*p)
+		}
+	}
+}
+
+func BenchmarkTabulated(b *testing.B) {
+	var rng = New()
+	var dist, _ = NewTabulated(func(x float64) float64 { return math.Exp(-x * x / 2) }, -5, 5, 1024)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dist.Sample(rng)
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	var rng = newSeededGen()
+	for _, dist := range []Distribution[float64]{
+		Normal{10, 2},
+		Exponential{0.5},
+		Uniform{-3, 5},
+		Shifted[float64]{Exponential{2}, -1},
+		Scaled[float64]{Normal{1, 3}, -2},
+	} {
+		var m = dist.(Moments)
+		checkMoments(t, fmt.Sprintf("%#v", dist), func() float64 { return dist.Sample(rng) }, m.Mean(), m.Variance())
+	}
+	var die = UniformInt{1, 6}
+	checkMoments(t, "UniformInt{1, 6}", func() float64 {
+		var x = die.Sample(rng)
+		if x < 1 || x > 6 {
+			t.Fatalf("UniformInt{1, 6} returned %d", x)
+		}
+		return float64(x)
+	}, die.Mean(), die.Variance())
+	if die.Mean() != 3.5 || die.Variance() != 35.0/12 {
+		t.Errorf("UniformInt{1, 6} has mean %v and variance %v, want 3.5 and %v", die.Mean(), die.Variance(), 35.0/12)
+	}
+
+	var mixture, err = NewMixture([]Distribution[float64]{Normal{-2, 1}, Normal{3, 0.5}, Exponential{1}}, []float64{1, 2, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mean, variance := mixture.Mean(), mixture.Variance(); math.Abs(mean-4.0/3) > 1e-12 || math.Abs(variance-(1.0/3*5+2.0/3*9.25-16.0/9)) > 1e-12 {
+		t.Errorf("mixture has mean %v and variance %v", mean, variance)
+	}
+	checkDistribution(t, "Mixture", func() float64 { return mixture.Sample(rng) }, func(x float64) float64 {
+		return math.Erfc(-(x+2)/math.Sqrt2)/6 + math.Erfc(-(x-3)/0.5/math.Sqrt2)/3
+	})
+
+	var truncated = Truncated[float64]{Normal{0, 1}, -1, 2}
+	var mass = (math.Erf(2/math.Sqrt2) - math.Erf(-1/math.Sqrt2)) / 2
+	checkDistribution(t, "Truncated", func() float64 { return truncated.Sample(rng) }, func(x float64) float64 {
+		return (math.Erf(x/math.Sqrt2) - math.Erf(-1/math.Sqrt2)) / 2 / mass
+	})
+	var truncatedDie = Truncated[int]{UniformInt{1, 6}, 2, 3}
+	for i := 0; i < 1000; i++ {
+		if x := truncatedDie.Sample(rng); x != 2 && x != 3 {
+			t.Fatalf("Truncated[int] returned %d, outside of [2, 3]", x)
+		}
+	}
+	if _, ok := Distribution[float64](truncated).(Moments); ok {
+		t.Error("Truncated implements Moments")
+	}
+
+	// Wrappers pass NaN through when the moments are unknown
+	var tabulated, _ = NewTabulated(func(float64) float64 { return 1 }, 0, 1, 1)
+	var unknown, _ = NewMixture([]Distribution[float64]{Normal{0, 1}, Scaled[float64]{tabulated, 2}}, []float64{1, 1})
+	if !math.IsNaN(unknown.Mean()) || !math.IsNaN(unknown.Variance()) {
+		t.Errorf("mixture with unknown moments has mean %v and variance %v, want NaN", unknown.Mean(), unknown.Variance())
+	}
+
+	for _, weights := range [][]float64{{1}, {1, -1}, {0, 0}, {1, math.NaN()}} {
+		if _, err := NewMixture([]Distribution[float64]{Normal{0, 1}, Normal{1, 1}}, weights); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewMixture with weights %v returned %v, want ErrInvalidArgument", weights, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	var rng = newSeededGen()
+	for _, c := range []struct{ spec, canonical string }{
+		{"normal(10, 2)", "normal(10, 2)"},
+		{"  normal( 1e1 ,2.000 ) ", "normal(10, 2)"},
+		{"exp(0.5)", "exp(0.5)"},
+		{"uniform(-1,6)", "uniform(-1, 6)"},
+		{"choice(1:3, 2.5:1, -0:0.5)", "choice(1:3, 2.5:1, 0:0.5)"},
+	} {
+		var sampler, err = ParseFloat64(rng, c.spec)
+		if err != nil {
+			t.Errorf("ParseFloat64(%q) returned %v", c.spec, err)
+			continue
+		}
+		if sampler.String() != c.canonical {
+			t.Errorf("ParseFloat64(%q).String() = %q, want %q", c.spec, sampler.String(), c.canonical)
+		}
+		var again, _ = ParseFloat64(rng, sampler.String())
+		if again == nil || again.String() != c.canonical || !reflect.DeepEqual(again.Distribution(), sampler.Distribution()) {
+			t.Errorf("ParseFloat64(%q) doesn't round-trip", c.canonical)
+		}
+	}
+
+	var normal, _ = ParseFloat64(rng, "normal(10, 2)")
+	checkMoments(t, "normal(10, 2)", normal.Sample, 10, 4)
+	var exponential, _ = ParseFloat64(rng, "exp(0.5)")
+	checkDistribution(t, "exp(0.5)", exponential.Sample, func(x float64) float64 { return -math.Expm1(-x / 2) })
+	var uniform, _ = ParseFloat64(rng, "uniform(1, 6)")
+	checkDistribution(t, "uniform(1, 6)", uniform.Sample, func(x float64) float64 { return (x - 1) / 5 })
+
+	var labels, err = ParseString(rng, `choice(a:3, b:1, "two words":0, "":1e-300)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `choice(a:3, b:1, "two words":0, "":1e-300)`; labels.String() != want {
+		t.Errorf("String() = %q, want %q", labels.String(), want)
+	}
+	if again, err := ParseString(rng, labels.String()); err != nil || !reflect.DeepEqual(again.Distribution(), labels.Distribution()) {
+		t.Errorf("ParseString(%q) doesn't round-trip: %v", labels.String(), err)
+	}
+	var counts = map[string]int{}
+	const n = 100000
+	for i := 0; i < n; i++ {
+		counts[labels.Sample()]++
+	}
+	if counts["two words"] != 0 || counts[""] != 0 || math.Abs(float64(counts["a"])-0.75*n) > 5*math.Sqrt(n*0.75*0.25) {
+		t.Errorf("choice(a:3, b:1) sampled %v", counts)
+	}
+
+	for _, c := range []struct {
+		spec, token string
+		offset      int
+		invalidArg  bool
+	}{
+		{"", "", 0, false},
+		{"normal", "", 6, false},
+		{"gamma(1, 2)", "gamma", 0, false},
+		{"normal(10 2)", "2", 10, false},
+		{"normal(10, x)", "x", 11, false},
+		{"normal(10, -2)", "-2", 11, true},
+		{"normal(10)", ")", 9, false},
+		{"normal(10, 2, 3)", "3", 14, false},
+		{"normal(10, 2) x", "x", 14, false},
+		{"normal(a:10, 2)", "a", 7, false},
+		{"exp(0)", "0", 4, true},
+		{"exp(NaN)", "NaN", 4, true},
+		{"uniform(6, 1)", "1", 11, true},
+		{"choice()", ")", 7, false},
+		{"choice(1:1, 2)", "2", 12, false},
+		{"choice(1:1, 1:2)", "1", 12, false},
+		{"choice(a:1)", "a", 7, false},
+		{"choice(1:-1)", "-1", 9, true},
+		{"choice(1:0, 2:0)", ")", 15, true},
+		{`choice("a:1)`, `"a:1)`, 7, false},
+	} {
+		var _, err = ParseFloat64(rng, c.spec)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Offset != c.offset || parseErr.Token != c.token {
+			t.Errorf("ParseFloat64(%q) returned %v, want an error at offset %d (%q)", c.spec, err, c.offset, c.token)
+			continue
+		}
+		if errors.Is(err, ErrInvalidArgument) != c.invalidArg {
+			t.Errorf("ParseFloat64(%q) returned %v, errors.Is(err, ErrInvalidArgument) should be %v", c.spec, err, c.invalidArg)
+		}
+	}
+	if _, err := ParseString(rng, "normal(0, 1)"); err == nil {
+		t.Error(`ParseString("normal(0, 1)") succeeded`)
+	}
+	if _, err := ParseString(rng, "choice(a:1, a:2)"); err == nil {
+		t.Error(`ParseString("choice(a:1, a:2)") succeeded`)
+	}
+}
+
+func TestEmpirical(t *testing.T) {
+	var rng = newSeededGen()
+	var observations = []float64{3, 0, 1, 1}
+	var discrete, err = NewEmpirical(observations, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	observations[0] = 100 // Must have been copied
+	var counts = map[float64]int{}
+	const n = 100000
+	for i := 0; i < n; i++ {
+		counts[discrete.Sample(rng)]++
+	}
+	for x, p := range map[float64]float64{0: 0.25, 1: 0.5, 3: 0.25} {
+		if math.Abs(float64(counts[x])-n*p) > 5*math.Sqrt(n*p*(1-p)) {
+			t.Errorf("Empirical sampled %v %d times, want about %v", x, counts[x], n*p)
+		}
+	}
+	checkMoments(t, "Empirical", func() float64 { return discrete.Sample(rng) }, discrete.Mean(), discrete.Variance())
+	if discrete.Mean() != 1.25 {
+		t.Errorf("Empirical has mean %v, want 1.25", discrete.Mean())
+	}
+
+	interpolated, err := NewEmpirical([]float64{3, 0, 1}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Half the mass spread evenly over [0, 1) and half over [1, 3)
+	checkDistribution(t, "Empirical (interpolated)", func() float64 { return interpolated.Sample(rng) }, func(x float64) float64 {
+		if x < 1 {
+			return x / 2
+		}
+		return 0.5 + (x-1)/4
+	})
+	checkMoments(t, "Empirical (interpolated)", func() float64 { return interpolated.Sample(rng) }, interpolated.Mean(), interpolated.Variance())
+	if mean := interpolated.Mean(); math.Abs(mean-1.25) > 1e-12 {
+		t.Errorf("interpolated Empirical has mean %v, want 1.25", mean)
+	}
+	single, _ := NewEmpirical([]float64{7}, true)
+	if x := single.Sample(rng); x != 7 || single.Variance() != 0 {
+		t.Errorf("single observation Empirical returned %v with variance %v", x, single.Variance())
+	}
+
+	var data = []float64{-2, -1.5, 0, 0.5, 3, 3.2, 4}
+	kde, err := NewKDE(data, 0.4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDistribution(t, "KDE", func() float64 { return kde.Sample(rng) }, func(x float64) float64 {
+		var sum float64
+		for _, y := range data {
+			sum += math.Erfc(-(x-y)/0.4/math.Sqrt2) / 2
+		}
+		return sum / float64(len(data))
+	})
+	checkMoments(t, "KDE", func() float64 { return kde.Sample(rng) }, kde.Mean(), kde.Variance())
+
+	// sd = sqrt(2.5), IQR = 4 - 2 = 2
+	var want = 0.9 * math.Min(math.Sqrt(2.5), 2/1.34) * math.Pow(5, -0.2)
+	if got := SilvermanBandwidth([]float64{5, 4, 3, 2, 1}); math.Abs(got-want) > 1e-12 {
+		t.Errorf("SilvermanBandwidth(1..5) = %v, want %v", got, want)
+	}
+	// Zero IQR falls back to the standard deviation
+	want = 0.9 * math.Sqrt(24.0/9) * math.Pow(6, -0.2)
+	if got := SilvermanBandwidth([]float64{1, 1, 1, 1, 1, -3}); math.Abs(got-want) > 1e-12 {
+		t.Errorf("SilvermanBandwidth with zero IQR = %v, want %v", got, want)
+	}
+	if got := SilvermanBandwidth([]float64{2}); got != 0 {
+		t.Errorf("SilvermanBandwidth of one observation = %v, want 0", got)
+	}
+
+	for _, c := range []struct {
+		observations []float64
+		bandwidth    float64
+	}{
+		{nil, 1},
+		{[]float64{1, math.NaN()}, 1},
+		{[]float64{1, math.Inf(-1)}, 1},
+		{[]float64{1, 2}, -1},
+		{[]float64{1, 2}, math.Inf(1)},
+	} {
+		if _, err := NewKDE(c.observations, c.bandwidth); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewKDE(%v, %v) returned %v, want ErrInvalidArgument", c.observations, c.bandwidth, err)
+		}
+	}
+	if _, err := NewEmpirical([]float64{math.NaN()}, true); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("NewEmpirical with NaN returned %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestPiecewise(t *testing.T) {
+	var rng = newSeededGen()
+	var constant, err = NewPiecewiseConstant([]float64{-1, 0, 2, 5}, []float64{2, 0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Masses 2, 0, 3 out of 5
+	checkDistribution(t, "PiecewiseConstant", func() float64 {
+		var x = constant.Sample(rng)
+		if x >= 0 && x < 2 {
+			t.Fatalf("PiecewiseConstant returned %v from a segment with zero density", x)
+		}
+		return x
+	}, func(x float64) float64 {
+		switch {
+		case x < 0:
+			return 2 * (x + 1) / 5
+		case x < 2:
+			return 0.4
+		default:
+			return 0.4 + (x-2)/5
+		}
+	})
+	if want := []float64{0.4, 0, 0.2}; !reflect.DeepEqual(constant.Densities(), want) {
+		t.Errorf("Densities() = %v, want %v", constant.Densities(), want)
+	}
+
+	linear, err := NewPiecewiseLinear([]float64{0, 1, 3}, []float64{0, 2, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Masses 1 and 4 out of 5
+	checkDistribution(t, "PiecewiseLinear", func() float64 {
+		var x = linear.Sample(rng)
+		if x < 0 || x > 3 {
+			t.Fatalf("PiecewiseLinear returned %v, outside of [0, 3]", x)
+		}
+		return x
+	}, func(x float64) float64 {
+		if x < 1 {
+			return x * x / 5
+		}
+		return 0.2 + 2*(x-1)/5
+	})
+	if want := []float64{0, 1, 3}; !reflect.DeepEqual(linear.Boundaries(), want) {
+		t.Errorf("Boundaries() = %v, want %v", linear.Boundaries(), want)
+	}
+
+	for _, c := range []struct {
+		boundaries, densities []float64
+	}{
+		{[]float64{0}, nil},
+		{[]float64{0, 1, 1}, []float64{1, 1}},
+		{[]float64{0, 2, 1}, []float64{1, 1}},
+		{[]float64{0, math.NaN()}, []float64{1}},
+		{[]float64{0, 1}, []float64{1, 1}},
+		{[]float64{0, 1, 2}, []float64{1, -1}},
+		{[]float64{0, 1, 2}, []float64{0, 0}},
+		{[]float64{-math.MaxFloat64, math.MaxFloat64}, []float64{1}},
+	} {
+		if _, err := NewPiecewiseConstant(c.boundaries, c.densities); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewPiecewiseConstant(%v, %v) returned %v, want ErrInvalidArgument", c.boundaries, c.densities, err)
+		}
+		var linearDensities = append(c.densities, 0)
+		if _, err := NewPiecewiseLinear(c.boundaries, linearDensities); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewPiecewiseLinear(%v, %v) returned %v, want ErrInvalidArgument", c.boundaries, linearDensities, err)
+		}
+	}
+}
+
+func TestLockedGen(t *testing.T) {
+	// locked_gen.go must be regenerated whenever a method of *Gen is added or changed
+	var genType, lockedType = reflect.TypeOf(&Gen{}), reflect.TypeOf(&LockedGen{})
+	for i := 0; i < genType.NumMethod(); i++ {
+		var method = genType.Method(i)
+		if method.Name == "Shuffle" {
+			continue
+		}
+		var locked, ok = lockedType.MethodByName(method.Name)
+		if !ok {
+			t.Errorf("LockedGen is missing %s(); run go generate", method.Name)
+			continue
+		}
+		// Drop the receivers before comparing
+		var want, got = method.Type, locked.Type
+		if want.NumIn() != got.NumIn() || want.NumOut() != got.NumOut() {
+			t.Errorf("LockedGen.%s has type %v, want %v; run go generate", method.Name, got, want)
+			continue
+		}
+		for j := 1; j < want.NumIn(); j++ {
+			if want.In(j) != got.In(j) {
+				t.Errorf("LockedGen.%s has type %v, want %v; run go generate", method.Name, got, want)
+			}
+		}
+		for j := 0; j < want.NumOut(); j++ {
+			if want.Out(j) != got.Out(j) {
+				t.Errorf("LockedGen.%s has type %v, want %v; run go generate", method.Name, got, want)
+			}
+		}
+	}
+
+	var rng, locked = newSeededGen(), NewLockedGen(New())
+	locked.ManualSeed(0x5eed)
+	for i := 0; i < 100; i++ {
+		if x, y := rng.Intn(1000), locked.Intn(1000); x != y {
+			t.Fatalf("LockedGen returned %d, want %d from the same seed", y, x)
+		}
+	}
+
+	// Values from a generator whose state was corrupted by a race would repeat
+	const goroutines, perGoroutine = 8, 10000
+	var results = make(chan []uint64, goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			var values = make([]uint64, perGoroutine)
+			for i := range values {
+				values[i] = locked.Uint64()
+			}
+			results <- values
+		}()
+	}
+	var seen = make(map[uint64]bool, goroutines*perGoroutine)
+	for g := 0; g < goroutines; g++ {
+		for _, x := range <-results {
+			if seen[x] {
+				t.Fatalf("LockedGen returned %d twice", x)
+			}
+			seen[x] = true
+		}
+	}
+}
+
+func TestPackageLevelFunctions(t *testing.T) {
+	const goroutines = 8
+	var done = make(chan error, goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			for i := 0; i < 10000; i++ {
+				if x := Intn(10); x < 0 || x >= 10 {
+					done <- fmt.Errorf("Intn(10) returned %d", x)
+					return
+				}
+				if x := IntBetween(-3, 3); x < -3 || x > 3 {
+					done <- fmt.Errorf("IntBetween(-3, 3) returned %d", x)
+					return
+				}
+				if x := Float64(); x < 0 || x >= 1 {
+					done <- fmt.Errorf("Float64() returned %v", x)
+					return
+				}
+				if x := Float64Range(2, 3); x < 2 || x >= 3 {
+					done <- fmt.Errorf("Float64Range(2, 3) returned %v", x)
+					return
+				}
+				if x := ExpFloat64(); x < 0 {
+					done <- fmt.Errorf("ExpFloat64() returned %v", x)
+					return
+				}
+			}
+			done <- nil
+		}()
+	}
+	for g := 0; g < goroutines; g++ {
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}
+	checkMoments(t, "NormFloat64", NormFloat64, 0, 1)
+	var perm = Perm(10)
+	sort.Ints(perm)
+	for i, x := range perm {
+		if x != i {
+			t.Fatalf("Perm(10) isn't a permutation: %v", perm)
+		}
+	}
+	var slice = []int{0, 1, 2, 3, 4}
+	Shuffle(nil, slice)
+	sort.Ints(slice)
+	if !reflect.DeepEqual(slice, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Shuffle(nil, ...) lost elements: %v", slice)
+	}
+}
+
+func BenchmarkMathRandUint64Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rand.Uint64()
+		}
+	})
+}
+
+func BenchmarkUint64Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			Uint64()
+		}
+	})
+}
+
+func BenchmarkLockedUint64Parallel(b *testing.B) {
+	var rng = NewLockedGen(New())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rng.Uint64()
+		}
+	})
+}
+
+func BenchmarkLocalUint64Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		var rng = New()
+		for pb.Next() {
+			rng.Uint64()
+		}
+	})
+}
+
+func TestRunParallel(t *testing.T) {
+	const tasks = 2000
+	var run = func(rootSeed uint64) []uint64 {
+		var results = make([]uint64, tasks)
+		if err := RunParallel(context.Background(), rootSeed, tasks, func(i int, rng *Gen) error {
+			results[i] = rng.Uint64()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return results
+	}
+	var defaultProcs = runtime.GOMAXPROCS(0)
+	var first = run(42)
+	runtime.GOMAXPROCS(4)
+	var second = run(42)
+	runtime.GOMAXPROCS(defaultProcs)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("RunParallel results depend on GOMAXPROCS")
+	}
+	var rng = New()
+	var seen = make(map[uint64]bool, tasks)
+	for i, x := range first {
+		rng.ManualSeed(TaskSeed(42, i))
+		if want := rng.Uint64(); x != want {
+			t.Fatalf("task %d drew %d, want %d from TaskSeed()", i, x, want)
+		}
+		if seen[x] {
+			t.Fatalf("task %d drew %d, which an earlier task also drew", i, x)
+		}
+		seen[x] = true
+	}
+	if other := run(43); other[0] == first[0] {
+		t.Error("RunParallel gave the same stream to two different root seeds")
+	}
+
+	var failure = errors.New("failure")
+	var err = RunParallel(context.Background(), 1, tasks, func(i int, rng *Gen) error {
+		if i == 37 || i == 500 {
+			return fmt.Errorf("task %d: %w", i, failure)
+		}
+		return nil
+	})
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Index != 37 || !errors.Is(err, failure) {
+		t.Errorf("RunParallel returned %v, want the error from task 37", err)
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int64
+	err = RunParallel(ctx, 1, tasks, func(i int, rng *Gen) error {
+		calls.Add(1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls.Load() != 0 {
+		t.Errorf("RunParallel with a cancelled context returned %v after %d calls", err, calls.Load())
+	}
+	if err := RunParallel(context.Background(), 1, 0, nil); err != nil {
+		t.Errorf("RunParallel with no tasks returned %v", err)
+	}
+	if err := RunParallel(context.Background(), 1, -1, nil); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("RunParallel with -1 tasks returned %v, want ErrInvalidArgument", err)
+	}
+}
+
+func BenchmarkRunParallel(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		RunParallel(context.Background(), uint64(i), 1000, func(i int, rng *Gen) error {
+			rng.Uint64()
+			return nil
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package randshiro
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Returns the lexicographic rank of perm, a permutation of ints in the interval [0, len(perm))
+//
+// Makes no range checks on perm; len(perm) must be at most 20
+// for the rank to fit in a uint64, otherwise use PermRankBig()
+func PermRank(perm []int) uint64 {
+	var rank uint64
+	for i, digit := range lehmerCode(perm) {
+		rank = rank*uint64(len(perm)-i) + uint64(digit)
+	}
+	return rank
+}
+
+// Returns the permutation of ints in the interval [0, n) with the given lexicographic rank
+//
+// Makes no range checks on n/rank; rank must be less than PermCount(n)
+func PermUnrank(n int, rank uint64) []int {
+	var code = make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		var radix = uint64(n - i)
+		code[i] = int(rank % radix)
+		rank /= radix
+	}
+	return fromLehmerCode(code)
+}
+
+// Returns the lexicographic rank of perm, a permutation of ints in the interval [0, len(perm))
+//
+// Makes no range checks on perm
+func PermRankBig(perm []int) *big.Int {
+	var rank, temp = new(big.Int), new(big.Int)
+	for i, digit := range lehmerCode(perm) {
+		rank.Mul(rank, temp.SetInt64(int64(len(perm)-i)))
+		rank.Add(rank, temp.SetInt64(int64(digit)))
+	}
+	return rank
+}
+
+// Returns the permutation of ints in the interval [0, n) with the given lexicographic rank
+//
+// Makes no range checks on n/rank; rank must be in the interval [0, PermCountBig(n))
+func PermUnrankBig(n int, rank *big.Int) []int {
+	var code = make([]int, n)
+	var quotient, radix, digit = new(big.Int).Set(rank), new(big.Int), new(big.Int)
+	for i := n - 1; i >= 0; i-- {
+		quotient.QuoRem(quotient, radix.SetInt64(int64(n-i)), digit)
+		code[i] = int(digit.Int64())
+	}
+	return fromLehmerCode(code)
+}
+
+// Returns n!, the number of permutations of n ints
+//
+// Makes no range checks on n; n must be at most 20
+// for the result to fit in a uint64, otherwise use PermCountBig()
+func PermCount(n int) uint64 {
+	var count uint64 = 1
+	for i := 2; i <= n; i++ {
+		count *= uint64(i)
+	}
+	return count
+}
+
+// Returns n!, the number of permutations of n ints
+func PermCountBig(n int) *big.Int {
+	if n < 2 {
+		return big.NewInt(1)
+	}
+	return new(big.Int).MulRange(2, int64(n))
+}
+
+// Returns the lexicographic rank of combination, a k-combination of ints in the interval [0, n)
+// sorted in increasing order (as returned by Combination())
+//
+// Makes no range checks on n/combination; CombinationCount(n, len(combination))
+// must fit in a uint64, otherwise use CombinationRankBig()
+func CombinationRank(n int, combination []int) uint64 {
+	var rank uint64
+	var k = len(combination)
+	var next = 0
+	for i, value := range combination {
+		for ; next < value; next++ {
+			rank += CombinationCount(n-1-next, k-1-i)
+		}
+		next++
+	}
+	return rank
+}
+
+// Returns the k-combination of ints in the interval [0, n) with the given lexicographic rank,
+// sorted in increasing order
+//
+// Makes no range checks on n/k/rank; rank must be less than CombinationCount(n, k)
+func CombinationUnrank(n, k int, rank uint64) []int {
+	var combination = make([]int, k)
+	var next = 0
+	for i := range combination {
+		for {
+			var count = CombinationCount(n-1-next, k-1-i)
+			if rank < count {
+				break
+			}
+			rank -= count
+			next++
+		}
+		combination[i] = next
+		next++
+	}
+	return combination
+}
+
+// Returns the lexicographic rank of combination, a k-combination of ints in the interval [0, n)
+// sorted in increasing order (as returned by Combination())
+//
+// Makes no range checks on n/combination
+func CombinationRankBig(n int, combination []int) *big.Int {
+	var rank, count = new(big.Int), new(big.Int)
+	var k = len(combination)
+	var next = 0
+	for i, value := range combination {
+		for ; next < value; next++ {
+			rank.Add(rank, count.Binomial(int64(n-1-next), int64(k-1-i)))
+		}
+		next++
+	}
+	return rank
+}
+
+// Returns the k-combination of ints in the interval [0, n) with the given lexicographic rank,
+// sorted in increasing order
+//
+// Makes no range checks on n/k/rank; rank must be in the interval [0, CombinationCountBig(n, k))
+func CombinationUnrankBig(n, k int, rank *big.Int) []int {
+	var combination = make([]int, k)
+	var remaining, count = new(big.Int).Set(rank), new(big.Int)
+	var next = 0
+	for i := range combination {
+		for {
+			count.Binomial(int64(n-1-next), int64(k-1-i))
+			if remaining.Cmp(count) < 0 {
+				break
+			}
+			remaining.Sub(remaining, count)
+			next++
+		}
+		combination[i] = next
+		next++
+	}
+	return combination
+}
+
+// Returns n choose k, the number of k-combinations of n ints
+//
+// Returns 0 if k < 0 or k > n. Makes no other range checks on n/k;
+// the result must fit in a uint64, otherwise use CombinationCountBig()
+func CombinationCount(n, k int) uint64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	if k > n-k {
+		k = n - k
+	}
+	// Every intermediate value is itself a binomial coefficient no larger than
+	// the result, but the product before dividing needs 128 bits
+	var count uint64 = 1
+	for i := 0; i < k; i++ {
+		var high, low = bits.Mul64(count, uint64(n-i))
+		count, _ = bits.Div64(high, low, uint64(i+1))
+	}
+	return count
+}
+
+// Returns n choose k, the number of k-combinations of n ints
+//
+// Returns 0 if k < 0 or k > n
+func CombinationCountBig(n, k int) *big.Int {
+	if k < 0 || k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
+
+// Returns the Lehmer code of perm: for each index, the number
+// of later elements that are smaller than the element at that index
+func lehmerCode(perm []int) []int {
+	var code = make([]int, len(perm))
+	for i := range perm {
+		for _, later := range perm[i+1:] {
+			if later < perm[i] {
+				code[i]++
+			}
+		}
+	}
+	return code
+}
+
+// Returns the permutation whose Lehmer code is code
+func fromLehmerCode(code []int) []int {
+	var remaining = make([]int, len(code))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	var perm = make([]int, len(code))
+	for i, digit := range code {
+		perm[i] = remaining[digit]
+		remaining = append(remaining[:digit], remaining[digit+1:]...)
+	}
+	return perm
+}
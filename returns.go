@@ -33,6 +33,94 @@ func (rng *Gen) Uint64n(bound uint64) uint64 {
 	return high
 }
 
+// Returns a uint32 in the interval [0, 2^32)
+func (rng *Gen) Uint32() uint32 {
+	const bitsInUint32 = 32
+	return uint32(rng.next() >> bitsInUint32)
+}
+
+// Returns a uint32 in the interval [0, bound)
+//
+// Cheaper than Uint64n() since the rejection threshold is only computed
+// with 32 bit division on the rare occasions that it's needed
+func (rng *Gen) Uint32n(bound uint32) uint32 {
+	const bitsInUint32 = 32
+	var product = uint64(rng.Uint32()) * uint64(bound)
+	if uint32(product) < bound {
+		var threshold = -bound % bound
+		for uint32(product) < threshold {
+			product = uint64(rng.Uint32()) * uint64(bound)
+		}
+	}
+	return uint32(product >> bitsInUint32)
+}
+
+// Returns two independent uint32s in the interval [0, bound)
+//
+// Both values are usually taken from a single call to the backing generator,
+// with only a rejected half being redrawn
+func (rng *Gen) FastUint32n(bound uint32) (uint32, uint32) {
+	const bitsInUint32 = 32
+	var (
+		random64Bits = rng.next()
+		product1     = (random64Bits >> bitsInUint32) * uint64(bound)
+		product2     = (random64Bits & (1<<bitsInUint32 - 1)) * uint64(bound)
+	)
+	if uint32(product1) < bound || uint32(product2) < bound {
+		var threshold = -bound % bound
+		for uint32(product1) < threshold {
+			product1 = uint64(rng.Uint32()) * uint64(bound)
+		}
+		for uint32(product2) < threshold {
+			product2 = uint64(rng.Uint32()) * uint64(bound)
+		}
+	}
+	return uint32(product1 >> bitsInUint32), uint32(product2 >> bitsInUint32)
+}
+
+// Returns a non-negative int64 in the interval [0, 2^63)
+func (rng *Gen) Int63() int64 {
+	return int64(rng.next() >> 1)
+}
+
+// Returns a non-negative int32 in the interval [0, 2^31)
+func (rng *Gen) Int31() int32 {
+	return int32(rng.Uint32() >> 1)
+}
+
+// Returns a non-negative int
+func (rng *Gen) Int() int {
+	return int(uint(rng.next()) >> 1)
+}
+
+// Returns an int64 in the interval [0, bound)
+//
+// Makes no range checks on bound
+func (rng *Gen) Int64n(bound int64) int64 {
+	return int64(rng.Uint64n(uint64(bound)))
+}
+
+// Returns an int32 in the interval [0, bound)
+//
+// Makes no range checks on bound
+func (rng *Gen) Int32n(bound int32) int32 {
+	return int32(rng.Uint32n(uint32(bound)))
+}
+
+// Returns an int64 in the interval [0, bound)
+//
+// Equivalent to calling Int64n(), named for parity with math/rand
+func (rng *Gen) Int63n(bound int64) int64 {
+	return rng.Int64n(bound)
+}
+
+// Returns an int32 in the interval [0, bound)
+//
+// Equivalent to calling Int32n(), named for parity with math/rand
+func (rng *Gen) Int31n(bound int32) int32 {
+	return rng.Int32n(bound)
+}
+
 // Returns an int in the interval [0, bound)
 //
 // Makes no range checks on bound
@@ -42,9 +130,57 @@ func (rng *Gen) Intn(bound int) int {
 
 // Returns an int in the interval [lowerBound, upperBound)
 //
-// Makes no range checks on lowerBound/upperBound
+// Works across the full range of int, e.g. IntRange(math.MinInt, math.MaxInt).
+// Makes no other range checks on lowerBound/upperBound
 func (rng *Gen) IntRange(lowerBound, upperBound int) int {
-	return rng.Intn(upperBound-lowerBound) + lowerBound
+	return int(rng.Int64Range(int64(lowerBound), int64(upperBound)))
+}
+
+// Returns an int64 in the interval [lowerBound, upperBound)
+//
+// Works across the full range of int64, e.g. Int64Range(math.MinInt64, math.MaxInt64).
+// Makes no other range checks on lowerBound/upperBound
+func (rng *Gen) Int64Range(lowerBound, upperBound int64) int64 {
+	// The difference always fits in a uint64 even when it overflows an int64,
+	// and two's complement wraparound takes care of the addition
+	return int64(rng.Uint64Range(uint64(lowerBound), uint64(upperBound)))
+}
+
+// Returns a uint64 in the interval [lowerBound, upperBound)
+//
+// Makes no range checks on lowerBound/upperBound
+func (rng *Gen) Uint64Range(lowerBound, upperBound uint64) uint64 {
+	return rng.Uint64n(upperBound-lowerBound) + lowerBound
+}
+
+// Returns an int in the interval [lowerBound, upperBound]
+//
+// Unlike IntRange(), upperBound itself can be returned, so
+// IntBetween(math.MinInt, math.MaxInt) covers every int.
+// Makes no other range checks on lowerBound/upperBound
+func (rng *Gen) IntBetween(lowerBound, upperBound int) int {
+	return int(rng.Int64Between(int64(lowerBound), int64(upperBound)))
+}
+
+// Returns an int64 in the interval [lowerBound, upperBound]
+//
+// Unlike Int64Range(), upperBound itself can be returned, so
+// Int64Between(math.MinInt64, math.MaxInt64) covers every int64.
+// Makes no other range checks on lowerBound/upperBound
+func (rng *Gen) Int64Between(lowerBound, upperBound int64) int64 {
+	return int64(rng.Uint64Between(uint64(lowerBound), uint64(upperBound)))
+}
+
+// Returns a uint64 in the interval [lowerBound, upperBound]
+//
+// Makes no range checks on lowerBound/upperBound
+func (rng *Gen) Uint64Between(lowerBound, upperBound uint64) uint64 {
+	var span = upperBound - lowerBound + 1
+	// Only wraps around to zero when the interval is [0, 2^64)
+	if span == 0 {
+		return rng.next()
+	}
+	return rng.Uint64n(span) + lowerBound
 }
 
 // Returns a bool with n in m odds of being true
@@ -73,6 +209,112 @@ func (rng *Gen) Float32() float32 {
 	return float32(rng.Uint64bits(float32Bits)) / float32Denom
 }
 
+// Returns a uniformly distributed float64 in the interval (0.0, 1.0)
+func (rng *Gen) Float64Open() float64 {
+	// Rerolling zero is a 1 in 2^53 event
+	var temp = rng.Uint64bits(float64Bits)
+	for temp == 0 {
+		temp = rng.Uint64bits(float64Bits)
+	}
+	return float64(temp) / float64Denom
+}
+
+// Returns a uniformly distributed float64 in the interval (0.0, 1.0]
+func (rng *Gen) Float64OpenClosed() float64 {
+	// Generated with interval of [0, 2^53), then
+	// changed to interval of (0, 2^53]
+	var temp = rng.Uint64bits(float64Bits) + 1
+	return float64(temp) / float64Denom
+}
+
+// Returns a uniformly distributed float64 in the interval [0.0, 1.0]
+func (rng *Gen) Float64Closed() float64 {
+	return float64(rng.Uint64n(float64Denom+1)) / float64Denom
+}
+
+// Returns a uniformly distributed float32 in the interval (0.0, 1.0)
+func (rng *Gen) Float32Open() float32 {
+	// Rerolling zero is a 1 in 2^24 event
+	var temp = rng.Uint64bits(float32Bits)
+	for temp == 0 {
+		temp = rng.Uint64bits(float32Bits)
+	}
+	return float32(temp) / float32Denom
+}
+
+// Returns a uniformly distributed float32 in the interval (0.0, 1.0]
+func (rng *Gen) Float32OpenClosed() float32 {
+	var temp = rng.Uint64bits(float32Bits) + 1
+	return float32(temp) / float32Denom
+}
+
+// Returns a uniformly distributed float32 in the interval [0.0, 1.0]
+func (rng *Gen) Float32Closed() float32 {
+	return float32(rng.Uint32n(float32Denom+1)) / float32Denom
+}
+
+// Returns a uniformly distributed float64 in the interval [lowerBound, upperBound)
+//
+// upperBound is never returned, even when rounding would otherwise produce it.
+// Returns lowerBound if lowerBound == upperBound, and NaN if the interval is otherwise
+// empty or unbounded (lowerBound > upperBound, or either bound is NaN or infinite).
+// Makes no other range checks on lowerBound/upperBound
+func (rng *Gen) Float64Range(lowerBound, upperBound float64) float64 {
+	// No result would ever pass the reroll below, so it would never finish
+	if !(lowerBound < upperBound) || math.IsInf(lowerBound, -1) || math.IsInf(upperBound, 1) {
+		if lowerBound == upperBound {
+			return lowerBound
+		}
+		return math.NaN()
+	}
+	var width = upperBound - lowerBound
+	for {
+		var x float64
+		if math.IsInf(width, 0) {
+			// The width overflowed, so work with halves instead
+			x = 2 * (lowerBound/2 + rng.Float64()*(upperBound/2-lowerBound/2))
+		} else {
+			x = lowerBound + rng.Float64()*width
+		}
+		// Rounding can land on upperBound when lowerBound and upperBound
+		// are far apart in magnitude; rerolling keeps the result uniform
+		if x < upperBound {
+			return x
+		}
+	}
+}
+
+// Returns a uniformly distributed float32 in the interval [lowerBound, upperBound)
+//
+// upperBound is never returned, even when rounding would otherwise produce it.
+// Returns lowerBound if lowerBound == upperBound, and NaN if the interval is otherwise
+// empty or unbounded (lowerBound > upperBound, or either bound is NaN or infinite).
+// Makes no other range checks on lowerBound/upperBound
+func (rng *Gen) Float32Range(lowerBound, upperBound float32) float32 {
+	// No result would ever pass the reroll below, so it would never finish
+	if !(lowerBound < upperBound) || math.IsInf(float64(lowerBound), -1) || math.IsInf(float64(upperBound), 1) {
+		if lowerBound == upperBound {
+			return lowerBound
+		}
+		return float32(math.NaN())
+	}
+	var width = upperBound - lowerBound
+	for {
+		var x float32
+		if math.IsInf(float64(width), 0) {
+			// The width overflowed, so work with halves instead
+			x = 2 * (lowerBound/2 + rng.Float32()*(upperBound/2-lowerBound/2))
+		} else {
+			x = lowerBound + rng.Float32()*width
+		}
+		// Rounding can land on upperBound when lowerBound and upperBound
+		// are far apart in magnitude; rerolling keeps the result uniform
+		if x < upperBound {
+			return x
+		}
+	}
+}
+
 // Returns two independent and uniformly distributed float32s in the interval [0.0, 1.0)
 //
 // Don't cast the float32s produced by this function to float64:
@@ -88,6 +330,80 @@ func (rng *Gen) FastFloat32() (float32, float32) {
 	return float1, float2
 }
 
+// Returns a float64 in the interval [0.0, 1.0) where every representable
+// float64 in that interval can be returned, with probability equal to its distance
+// to the next representable float64
+//
+// Float64() only returns multiples of 2^-53, which is plenty for almost every use case.
+// This method exists for when the values very close to zero matter,
+// e.g. when estimating tail probabilities
+func (rng *Gen) FullFloat64() float64 {
+	const mantissaBits = float64Bits - 1
+	// Biased exponent of floats in the interval [0.5, 1.0)
+	const startingExponent = 1022
+	const bitsInUint64 = 64
+	const mantissaMask = 1<<mantissaBits - 1
+	// Almost always one draw is enough: the bits after the first set bit are
+	// still uniformly random, so as long as there are enough of them
+	// they can be reused as the mantissa
+	var random = rng.next()
+	var zeros = uint64(bits.LeadingZeros64(random))
+	if zeros < bitsInUint64-mantissaBits {
+		var exponent = startingExponent - zeros
+		return math.Float64frombits(exponent<<mantissaBits | random&mantissaMask)
+	}
+	var exponent = rng.fullExponent(startingExponent, random)
+	var mantissa = rng.Uint64bits(mantissaBits)
+	return math.Float64frombits(exponent<<mantissaBits | mantissa)
+}
+
+// Returns a float32 in the interval [0.0, 1.0) where every representable
+// float32 in that interval can be returned, with probability equal to its distance
+// to the next representable float32
+//
+// Float32() only returns multiples of 2^-24, which is plenty for almost every use case.
+// This method exists for when the values very close to zero matter,
+// e.g. when estimating tail probabilities
+func (rng *Gen) FullFloat32() float32 {
+	const mantissaBits = float32Bits - 1
+	// Biased exponent of floats in the interval [0.5, 1.0)
+	const startingExponent = 126
+	const bitsInUint64 = 64
+	const mantissaMask = 1<<mantissaBits - 1
+	// See FullFloat64()
+	var random = rng.next()
+	var zeros = uint64(bits.LeadingZeros64(random))
+	if zeros < bitsInUint64-mantissaBits {
+		var exponent = startingExponent - zeros
+		return math.Float32frombits(uint32(exponent<<mantissaBits | random&mantissaMask))
+	}
+	var exponent = rng.fullExponent(startingExponent, random)
+	var mantissa = rng.Uint64bits(mantissaBits)
+	return math.Float32frombits(uint32(exponent<<mantissaBits | mantissa))
+}
+
+// Returns a biased exponent that starts at startingExponent and is decremented
+// once per fair coin flip until the first heads, stopping at zero (subnormals)
+//
+// Each step down halves both the width of the binade and the odds of landing in it,
+// which is exactly what a uniform distribution over the reals needs.
+// The leading zeros of random are used as the first coin flips
+func (rng *Gen) fullExponent(startingExponent, random uint64) uint64 {
+	const bitsInUint64 = 64
+	var exponent = startingExponent
+	for {
+		var zeros = uint64(bits.LeadingZeros64(random))
+		if zeros >= exponent {
+			return 0
+		}
+		exponent -= zeros
+		if zeros < bitsInUint64 {
+			return exponent
+		}
+		random = rng.next()
+	}
+}
+
 // Returns two independent and normally distributed float64s
 // with mean = 0.0 and stddev = 1.0
 //
@@ -140,19 +456,132 @@ func (rng *Gen) NormalDist(mean, stddev float64) (float64, float64) {
 	return x*stddev + mean, y*stddev + mean
 }
 
+// Returns two independent and normally distributed float32s
+// with mean = 0.0 and stddev = 1.0
+//
+// Like FastFloat32(), both coordinates of each attempt come
+// from a single call to the backing generator
+func (rng *Gen) NormalFloat32() (float32, float32) {
+	const bitCount = float32Bits + 1
+	const shiftValues = 1 << float32Bits
+	const mask = 1<<bitCount - 1
+
+	// Same as Normal(), except each coordinate is an integer in the
+	// interval (-2^24, 2^24) taken from one half of a 50 bit roll,
+	// which maps to a float32 in the interval (-1.0, 1.0)
+	for {
+		var random50Bits = rng.Uint64bits(bitCount * 2)
+		var tempU = int32(random50Bits >> bitCount)
+		var tempV = int32(random50Bits & mask)
+		if tempU == 0 || tempV == 0 {
+			continue
+		}
+		var u = float32(tempU-shiftValues) / float32Denom
+		var v = float32(tempV-shiftValues) / float32Denom
+
+		var s = u*u + v*v
+		if s >= 1 || s == 0 {
+			continue
+		}
+		// math has no float32 functions, so only this step is done in float64
+		var scale = float32(math.Sqrt(-2 * math.Log(float64(s)) / float64(s)))
+		return u * scale, v * scale
+	}
+}
+
+// Returns two independent and normally distributed float32s
+// with user-defined mean and stddev
+//
+// Makes no range checks on mean/stddev
+func (rng *Gen) NormalDistFloat32(mean, stddev float32) (float32, float32) {
+	var x, y = rng.NormalFloat32()
+	return x*stddev + mean, y*stddev + mean
+}
+
+// Returns a von Mises distributed angle in the interval [-pi, pi)
+// with mean direction mu and concentration kappa
+//
+// The von Mises distribution is the circular analogue of the normal distribution,
+// with 1/kappa playing the role of the variance. Uses Best and Fisher's method
+// (https://doi.org/10.2307/2346732), falling back to a uniform angle when kappa is
+// negligible and to the normal approximation when kappa is too large for it to be accurate.
+// Makes no range checks on mu/kappa
+func (rng *Gen) VonMises(mu, kappa float64) float64 {
+	const uniformBelow = 1e-6
+	const normalAbove = 1e8
+	if kappa < uniformBelow {
+		return wrapAngle(2 * math.Pi * rng.Float64())
+	}
+	if kappa > normalAbove {
+		var x, _ = rng.Normal()
+		return wrapAngle(mu + x/math.Sqrt(kappa))
+	}
+	// Written in terms of s = 1/(2*kappa) so that r doesn't
+	// lose precision as kappa grows
+	var s = 0.5 / kappa
+	var r = s + math.Sqrt(1+s*s)
+	var z float64
+	for {
+		z = math.Cos(math.Pi * rng.Float64())
+		var d = z / (r + z)
+		var u = rng.Float64()
+		if u < 1-d*d || u <= (1-d)*math.Exp(d) {
+			break
+		}
+	}
+	var q = 1 / r
+	var f = (q + z) / (1 + q*z)
+	var theta = math.Acos(math.Max(-1, math.Min(1, f)))
+	if rng.Bool() {
+		theta = -theta
+	}
+	return wrapAngle(mu + theta)
+}
+
+// Returns a wrapped Cauchy distributed angle in the interval [-pi, pi)
+// with mean direction mu and scale gamma
+//
+// Makes no range checks on mu/gamma
+func (rng *Gen) WrappedCauchy(mu, gamma float64) float64 {
+	return wrapAngle(rng.Cauchy(mu, gamma))
+}
+
+// Returns a wrapped normal distributed angle in the interval [-pi, pi)
+// with mean direction mu and stddev sigma (before wrapping)
+//
+// Makes no range checks on mu/sigma
+func (rng *Gen) WrappedNormal(mu, sigma float64) float64 {
+	var x, _ = rng.Normal()
+	return wrapAngle(mu + sigma*x)
+}
+
+// Returns the angle in the interval [-pi, pi) equivalent to angle
+func wrapAngle(angle float64) float64 {
+	angle = math.Remainder(angle, 2*math.Pi)
+	if angle >= math.Pi {
+		angle -= 2 * math.Pi
+	} else if angle < -math.Pi {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
+
 // Returns an exponentially distributed float64 with
 // a rate constant (lambda) of 1
 //
 // Lambda can be adjusted with: Exponential() / lambda
 func (rng *Gen) Exponential() float64 {
-	// Generated with interval of [0, 2^53)
-	var temp = rng.Uint64bits(float64Bits)
-	// Changed to interval of (0, 2^53]
-	temp++
-	// Uniformly distributed float64 in the interval (0.0, 1.0]
-	var float = float64(temp) / float64Denom
 	// Random variate generation (see docs for link)
-	return -math.Log(float)
+	return -math.Log(rng.Float64OpenClosed())
+}
+
+// Returns an exponentially distributed float32 with
+// a rate constant (lambda) of 1
+//
+// Lambda can be adjusted with: ExponentialFloat32() / lambda
+func (rng *Gen) ExponentialFloat32() float32 {
+	// math has no float32 functions, so the logarithm is done in float64
+	return float32(-math.Log(float64(rng.Float32OpenClosed())))
 }
 
 // Returns a permutation of ints in the interval [0, n)
@@ -175,14 +604,15 @@ func (rng *Gen) Shuffle() {}
 // Performs a Fisher-Yates shuffle on the contents of slice
 //
 // If len(slice) > 1 and rng == nil then
-// Shuffle() will instantiate rng with New512pp()
-// before continuing as normal. If Shuffle() is being called as a
-// one-off it may be preferable to just pass nil to the rng
-// parameter.
+// Shuffle() will borrow the *Gen shared by the
+// package-level functions before continuing as normal.
+// If Shuffle() is being called as a one-off it may be
+// preferable to just pass nil to the rng parameter.
 func Shuffle[T any](rng *Gen, slice []T) {
 	if len(slice) > 1 {
 		if rng == nil {
-			rng = New512pp()
+			rng = pool.Get().(*Gen)
+			defer pool.Put(rng)
 		}
 		var swap = reflect.Swapper(slice)
 		for i := len(slice) - 1; i > 0; i-- {
@@ -21,7 +21,8 @@ type randomBitGenerator interface {
 // Instances are not threadsafe and they are not cryptographically secure
 //
 // It is highly recommended that each goroutine needing a source of
-// random values should create and own a unique Gen instance
+// random values should create and own a unique Gen instance.
+// Otherwise use the package-level functions, or wrap a single instance with NewLockedGen()
 type Gen struct{ randomBitGenerator }
 
 // Returns a seeded *Gen with backing Xoshiro256++ instance
@@ -66,9 +67,13 @@ func seed(state []uint64) {
 func alternateSeed(state []uint64, x uint64) {
 	for i := range state {
 		x += 0x9e3779b97f4a7c15
-		var z = x
-		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
-		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
-		state[i] = z ^ (z >> 31)
+		state[i] = mix64(x)
 	}
 }
+
+// The output function of SplitMix64
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,128 @@
+package randshiro
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Returns a uniformly distributed *big.Int in the interval [0, bound)
+//
+// Panics if bound <= 0
+func (rng *Gen) BigIntn(bound *big.Int) *big.Int {
+	if bound.Sign() <= 0 {
+		panic("randshiro: argument to BigIntn is <= 0")
+	}
+	const bitsInUint64 = 64
+	const bytesInUint64 = 8
+	var bitLen = bound.BitLen()
+	var wordCount = (bitLen + bitsInUint64 - 1) / bitsInUint64
+	// Number of bits that have to be cleared from the most significant word
+	// so that each attempt is in the interval [0, 2^bitLen)
+	var excessBits = uint(wordCount*bitsInUint64 - bitLen)
+	var buffer = make([]byte, wordCount*bytesInUint64)
+	var result = new(big.Int)
+	for {
+		// Big-endian, filling the least significant word first
+		for i := wordCount - 1; i >= 0; i-- {
+			var word = rng.next()
+			if i == 0 {
+				word >>= excessBits
+			}
+			for j := bytesInUint64 - 1; j >= 0; j-- {
+				buffer[i*bytesInUint64+j] = byte(word)
+				word >>= 8
+			}
+		}
+		// Since bound >= 2^(bitLen-1), at least half of all attempts succeed
+		if result.SetBytes(buffer).Cmp(bound) < 0 {
+			return result
+		}
+	}
+}
+
+// Returns the high and low halves of a uint128 in the interval [0, 2^128)
+func (rng *Gen) Uint128() (hi, lo uint64) {
+	hi = rng.next()
+	lo = rng.next()
+	return hi, lo
+}
+
+// Returns the high and low halves of a uint128 in the interval [0, bound),
+// where bound is given by its high and low halves
+//
+// Makes no range checks on bound; a bound of zero returns zero
+func (rng *Gen) Uint128n(boundHi, boundLo uint64) (hi, lo uint64) {
+	if boundHi == 0 {
+		return 0, rng.Uint64n(boundLo)
+	}
+	// Lemire's nearly divisionless method, widened to 128 bits:
+	// the high 128 bits of the 256 bit product of a random uint128
+	// and bound are the result, while the low 128 bits are used
+	// to detect the (rare) biased rolls that need to be rejected
+	var xHi, xLo = rng.Uint128()
+	var w3, w2, w1, w0 = mul128(xHi, xLo, boundHi, boundLo)
+	if less128(w1, w0, boundHi, boundLo) {
+		var thresholdHi, thresholdLo = negMod128(boundHi, boundLo)
+		for less128(w1, w0, thresholdHi, thresholdLo) {
+			xHi, xLo = rng.Uint128()
+			w3, w2, w1, w0 = mul128(xHi, xLo, boundHi, boundLo)
+		}
+	}
+	return w3, w2
+}
+
+// Returns the 256 bit product of (aHi, aLo) and (bHi, bLo) as four
+// 64 bit words, most significant first
+func mul128(aHi, aLo, bHi, bLo uint64) (w3, w2, w1, w0 uint64) {
+	var h00, l00 = bits.Mul64(aLo, bLo)
+	var h01, l01 = bits.Mul64(aLo, bHi)
+	var h10, l10 = bits.Mul64(aHi, bLo)
+	var h11, l11 = bits.Mul64(aHi, bHi)
+
+	var carry uint64
+	w0 = l00
+	w1, carry = bits.Add64(h00, l01, 0)
+	w2, carry = bits.Add64(h01, l11, carry)
+	w3 = h11 + carry
+	w1, carry = bits.Add64(w1, l10, 0)
+	w2, carry = bits.Add64(w2, h10, carry)
+	w3 += carry
+	return w3, w2, w1, w0
+}
+
+// Returns whether (aHi, aLo) < (bHi, bLo)
+func less128(aHi, aLo, bHi, bLo uint64) bool {
+	return aHi < bHi || (aHi == bHi && aLo < bLo)
+}
+
+// Returns (2^128 - bound) % bound for a bound of at least 2^64
+func negMod128(boundHi, boundLo uint64) (hi, lo uint64) {
+	// 2^128 - bound, which is also the starting remainder
+	var borrow uint64
+	lo, borrow = bits.Sub64(0, boundLo, 0)
+	hi, _ = bits.Sub64(0, boundHi, borrow)
+	// Since bound >= 2^64 the quotient is less than 2^64, so plain
+	// shift-and-subtract long division finishes in at most 64 steps
+	if bits.LeadingZeros64(hi) > bits.LeadingZeros64(boundHi) {
+		return hi, lo
+	}
+	var shift = uint(bits.LeadingZeros64(boundHi) - bits.LeadingZeros64(hi))
+	var dHi, dLo = shl128(boundHi, boundLo, shift)
+	for i := int(shift); i >= 0; i-- {
+		if !less128(hi, lo, dHi, dLo) {
+			lo, borrow = bits.Sub64(lo, dLo, 0)
+			hi, _ = bits.Sub64(hi, dHi, borrow)
+		}
+		dLo = dLo>>1 | dHi<<63
+		dHi >>= 1
+	}
+	return hi, lo
+}
+
+// Returns (hi, lo) << shift for a shift less than 64
+func shl128(hi, lo uint64, shift uint) (uint64, uint64) {
+	if shift == 0 {
+		return hi, lo
+	}
+	return hi<<shift | lo>>(64-shift), lo << shift
+}
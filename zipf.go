@@ -0,0 +1,89 @@
+package randshiro
+
+import "math"
+
+// Generates Zipf distributed uint64s bound to a *Gen, like the Zipf type from math/rand
+//
+// The probability of k in the interval [0, imax] is proportional to (v+k)^-s.
+// Unlike math/rand, s only needs to be greater than 0, so exponents at or below 1 work too
+type Zipf struct {
+	rng  *Gen
+	s    float64
+	v    float64
+	imax float64
+
+	hIntegralX1   float64
+	hIntegralImax float64
+	squeeze       float64
+}
+
+// Returns a *Zipf that draws from rng
+//
+// Returns an error unless s > 0, v >= 1, and both are finite
+func NewZipf(rng *Gen, s, v float64, imax uint64) (*Zipf, error) {
+	if err := checkPositive("NewZipf", "s", s); err != nil {
+		return nil, err
+	}
+	if !(v >= 1) || math.IsInf(v, 1) {
+		return nil, argumentError("NewZipf", "v", v, "must be finite and at least 1")
+	}
+	// Hörmann and Derflinger's rejection-inversion:
+	// https://doi.org/10.1145/235025.235029
+	// Each k owns the interval [k-0.5, k+0.5] under a continuous hat function,
+	// and inverting the hat's integral picks a candidate which is accepted unless
+	// it lands in the small sliver where the hat overshoots the real pmf
+	var zipf = &Zipf{rng: rng, s: s, v: v, imax: float64(imax)}
+	zipf.hIntegralX1 = zipf.hIntegral(0.5) - zipf.h(0)
+	zipf.hIntegralImax = zipf.hIntegral(zipf.imax + 0.5)
+	zipf.squeeze = 1 - zipf.hIntegralInverse(zipf.hIntegral(1.5)-zipf.h(1))
+	return zipf, nil
+}
+
+// Returns a Zipf distributed uint64 in the interval [0, imax]
+func (zipf *Zipf) Uint64() uint64 {
+	for {
+		var u = zipf.hIntegralImax + zipf.rng.Float64()*(zipf.hIntegralX1-zipf.hIntegralImax)
+		var x = zipf.hIntegralInverse(u)
+		var k = math.Min(math.Max(math.Floor(x+0.5), 0), zipf.imax)
+		if k-x <= zipf.squeeze || u >= zipf.hIntegral(k+0.5)-zipf.h(k) {
+			return uint64(k)
+		}
+	}
+}
+
+// The hat function (v+x)^-s
+func (zipf *Zipf) h(x float64) float64 {
+	return math.Exp(-zipf.s * math.Log(zipf.v+x))
+}
+
+// ((v+x)^(1-s) - 1) / (1-s), an antiderivative of h() that stays accurate as s approaches 1
+func (zipf *Zipf) hIntegral(x float64) float64 {
+	var logX = math.Log(zipf.v + x)
+	return expm1OverX((1-zipf.s)*logX) * logX
+}
+
+// Inverse of hIntegral()
+func (zipf *Zipf) hIntegralInverse(x float64) float64 {
+	var t = x * (1 - zipf.s)
+	// Guards against round-off pushing t outside of the domain of log1p
+	if t < -1 {
+		t = -1
+	}
+	return math.Exp(log1pOverX(t)*x) - zipf.v
+}
+
+// Returns log(1+x)/x, continuous at x = 0
+func log1pOverX(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Log1p(x) / x
+	}
+	return 1 - x/2
+}
+
+// Returns (exp(x)-1)/x, continuous at x = 0
+func expm1OverX(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Expm1(x) / x
+	}
+	return 1 + x/2
+}